@@ -8,6 +8,9 @@ import (
 
 	"css-color-variable-creator/pkg/colors"
 	"css-color-variable-creator/pkg/generator"
+	"css-color-variable-creator/pkg/palette"
+	"css-color-variable-creator/pkg/terminal"
+	"css-color-variable-creator/pkg/tokens"
 
 	"github.com/spf13/cobra"
 )
@@ -25,12 +28,106 @@ var Cmd = &cobra.Command{
 		format, _ := cmd.Flags().GetString("format")
 		outputFile, _ := cmd.Flags().GetString("output-file")
 		outputVariableFile, _ := cmd.Flags().GetString("output-variable-file")
+		themesFile, _ := cmd.Flags().GetString("themes")
+		naming, _ := cmd.Flags().GetString("naming")
+		nameTemplate, _ := cmd.Flags().GetString("name-template")
+		namesFile, _ := cmd.Flags().GetString("names")
+		tokensFile, _ := cmd.Flags().GetString("tokens")
+		tolerance, _ := cmd.Flags().GetFloat64("tolerance")
+		paletteFile, _ := cmd.Flags().GetString("palette")
+		snapThreshold, _ := cmd.Flags().GetFloat64("snap-threshold")
+		inPlace, _ := cmd.Flags().GetBool("in-place")
+		backup, _ := cmd.Flags().GetBool("backup")
+		force, _ := cmd.Flags().GetBool("force")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		scssImport, _ := cmd.Flags().GetString("scss-import")
+		variablesFormats, _ := cmd.Flags().GetStringSlice("variables-format")
+		outputTokensFile, _ := cmd.Flags().GetString("output-tokens-file")
+		sortOrder, _ := cmd.Flags().GetString("sort")
+		group, _ := cmd.Flags().GetBool("group")
+		annotate, _ := cmd.Flags().GetString("annotate")
+		scope, _ := cmd.Flags().GetString("scope")
+		layer, _ := cmd.Flags().GetString("layer")
+		tailwindFile, _ := cmd.Flags().GetString("tailwind")
+		tailwindValues, _ := cmd.Flags().GetString("tailwind-values")
+		jsModuleFile, _ := cmd.Flags().GetString("js-module")
+		androidRes, _ := cmd.Flags().GetString("android-res")
+		iosAssets, _ := cmd.Flags().GetString("ios-assets")
+		darkTheme, _ := cmd.Flags().GetString("dark-theme")
+		paletteFiles, _ := cmd.Flags().GetStringSlice("export-palette")
+		reportFile, _ := cmd.Flags().GetString("report")
+		reportSourceURL, _ := cmd.Flags().GetString("report-source-url")
+		fallback, _ := cmd.Flags().GetBool("fallback")
+		legacyFallback, _ := cmd.Flags().GetBool("legacy-fallback")
 
 		// Validate format flag
 		if format != "" && format != "hex" && format != "rgb" && format != "rgba" {
 			return fmt.Errorf("invalid format specified. Must be one of: hex, rgb, rgba")
 		}
 
+		// Validate scss-import flag, even when the input is not SCSS
+		switch scssImport {
+		case "", "use", "import", "none":
+		default:
+			return fmt.Errorf("invalid --scss-import %q. Must be one of: use, import, none", scssImport)
+		}
+
+		variablesFormat, dtcg, err := parseVariablesFormats(variablesFormats)
+		if err != nil {
+			return err
+		}
+		if variablesFormat == "" && themesFile != "" {
+			return fmt.Errorf("--themes needs a css or sass-css variables file")
+		}
+
+		if tailwindFile != "" {
+			switch tailwindValues {
+			case "", "var":
+				if variablesFormat != "css" && variablesFormat != "sass-css" {
+					return fmt.Errorf("--tailwind-values var needs custom properties; use --variables-format css or sass-css, or --tailwind-values literal")
+				}
+			case "literal":
+			default:
+				return fmt.Errorf("invalid --tailwind-values %q. Must be one of: var, literal", tailwindValues)
+			}
+		}
+
+		sassVariables := variablesFormat == "sass" || variablesFormat == "sass-css"
+		if sassVariables && filepath.Ext(inputFile) != ".scss" {
+			return fmt.Errorf("--variables-format %s requires an .scss input file", variablesFormat)
+		}
+		if variablesFormat == "sass" && (fallback || legacyFallback) {
+			return fmt.Errorf("--fallback and --legacy-fallback only apply to var() references and cannot be used with --variables-format sass")
+		}
+
+		if tokensFile != "" && (namesFile != "" || nameTemplate != "") {
+			return fmt.Errorf("--tokens names colors after the tokens and cannot be used with --names or --name-template")
+		}
+		if tokensFile != "" && paletteFile != "" {
+			return fmt.Errorf("--tokens and --palette cannot be used together")
+		}
+
+		for _, paletteFile := range paletteFiles {
+			switch filepath.Ext(paletteFile) {
+			case ".gpl", ".ase", ".csv":
+			default:
+				return fmt.Errorf("unsupported palette file %s. Must end in .gpl, .ase or .csv", paletteFile)
+			}
+		}
+
+		if inPlace {
+			if outputFile != "" {
+				return fmt.Errorf("--output-file cannot be used with --in-place")
+			}
+			if !force && !dryRun {
+				if err := checkGitClean(inputFile); err != nil {
+					return err
+				}
+			}
+		} else if backup {
+			return fmt.Errorf("--backup requires --in-place")
+		}
+
 		// Scan the input file for colors
 		matches, err := colors.ScanFile(inputFile)
 		if err != nil {
@@ -42,6 +139,72 @@ var Cmd = &cobra.Command{
 			return nil
 		}
 
+		var unmatched []colors.ColorMatch
+		if tokensFile != "" {
+			// Name colors after the matching design tokens
+			tokenSet, notes, err := tokens.Load(tokensFile)
+			if err != nil {
+				return err
+			}
+			for _, note := range notes {
+				fmt.Println("Note:", note)
+			}
+			matches, unmatched, notes = tokens.Match(matches, tokenSet, tolerance)
+			for _, note := range notes {
+				fmt.Println("Note:", note)
+			}
+			printUnmatched(unmatched)
+			if len(matches) == 0 {
+				fmt.Println("No colors match a token")
+				return nil
+			}
+		} else {
+			// Name variables using the selected strategy
+			notes, err := colors.ApplyNaming(matches, naming)
+			if err != nil {
+				return err
+			}
+			for _, note := range notes {
+				fmt.Println("Note:", note)
+			}
+			if nameTemplate != "" {
+				if err := colors.ApplyNameTemplate(matches, nameTemplate); err != nil {
+					return err
+				}
+			}
+
+			// Use the swatch names of a designer's palette
+			if paletteFile != "" {
+				swatches, err := palette.Load(paletteFile)
+				if err != nil {
+					return err
+				}
+				for _, skipped := range swatches.Skipped {
+					fmt.Printf("Note: skipped palette swatch at %s\n", skipped)
+				}
+				named, notes := palette.Apply(matches, swatches.Swatches, snapThreshold)
+				for _, note := range notes {
+					fmt.Println("Note:", note)
+				}
+				fmt.Printf("Named %d colors after swatches from %s\n", named, paletteFile)
+			}
+
+			for _, line := range colors.EnsureUniqueNames(matches) {
+				fmt.Println("Note:", line)
+			}
+		}
+
+		// Keep names stable across runs
+		var lock *colors.NameLock
+		var addedNames []string
+		if namesFile != "" {
+			lock, err = colors.LoadNameLock(namesFile)
+			if err != nil {
+				return err
+			}
+			addedNames = lock.Apply(matches)
+		}
+
 		// Convert colors to specified format if requested
 		if format != "" {
 			for i := range matches {
@@ -57,7 +220,7 @@ var Cmd = &cobra.Command{
 		baseDir := outputDir
 		if baseDir == "" {
 			baseDir = filepath.Dir(inputFile)
-		} else {
+		} else if !dryRun {
 			// Create output directory if it doesn't exist
 			if err := os.MkdirAll(baseDir, 0755); err != nil {
 				return fmt.Errorf("failed to create output directory: %w", err)
@@ -68,11 +231,19 @@ var Cmd = &cobra.Command{
 		baseFileName := strings.TrimSuffix(filepath.Base(inputFile), filepathExt)
 
 		variablesFileName := baseFileName + "-variables" + filepathExt
+		if sassVariables {
+			variablesFileName = "_" + baseFileName + "-colors.scss"
+		}
 
 		if outputVariableFile != "" {
 			variablesFileName = outputVariableFile
 		}
 
+		tokensFileName := baseFileName + "-tokens.json"
+		if outputTokensFile != "" {
+			tokensFileName = outputTokensFile
+		}
+
 		modifiedFileName := baseFileName + "-with-variables" + filepathExt
 
 		if outputFile != "" {
@@ -80,26 +251,216 @@ var Cmd = &cobra.Command{
 		}
 
 		variablesFile := filepath.Join(baseDir, variablesFileName)
+		dtcgFile := filepath.Join(baseDir, tokensFileName)
 		modifiedFile := filepath.Join(baseDir, modifiedFileName)
+		if inPlace {
+			modifiedFile = inputFile
+		}
 
-		// Generate the variables file
-		err = generator.GenerateVariablesFile(matches, variablesFile)
-		if err != nil {
-			return fmt.Errorf("failed to generate variables file: %w", err)
+		opts := generator.VariablesOptions{
+			Format:   variablesFormat,
+			Sort:     sortOrder,
+			Group:    group,
+			Annotate: annotate,
+			Source:   filepath.Base(inputFile),
+			Scope:    scope,
+			Layer:    layer,
+		}
+		if themesFile != "" {
+			opts.Themes, err = generator.LoadThemes(themesFile)
+			if err != nil {
+				return err
+			}
+		}
+
+		modifiedOpts := generator.ModifiedOptions{
+			VariablesPath:  variablesFile,
+			SCSSImport:     scssImport,
+			Fallback:       fallback,
+			LegacyFallback: legacyFallback,
+		}
+		if variablesFormat == "sass" {
+			modifiedOpts.Reference = "sass"
+		}
+
+		// Render the variables file and the other exports
+		var outputs []generatedFile
+		if variablesFormat != "" {
+			content, err := generator.RenderVariablesFile(matches, opts)
+			if err != nil {
+				return fmt.Errorf("failed to generate variables file: %w", err)
+			}
+			outputs = append(outputs, generatedFile{kind: "variables file", path: variablesFile, content: content})
+		} else {
+			modifiedOpts.SCSSImport = "none"
+		}
+		if dtcg {
+			content, err := generator.RenderDTCGFile(matches)
+			if err != nil {
+				return fmt.Errorf("failed to generate tokens file: %w", err)
+			}
+			outputs = append(outputs, generatedFile{kind: "design tokens file", path: dtcgFile, content: content})
+		}
+		if tailwindFile != "" {
+			content, err := generator.RenderTailwindConfig(matches, generator.TailwindOptions{
+				Module: tailwindModule(tailwindFile),
+				Values: tailwindValues,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to generate Tailwind config: %w", err)
+			}
+			outputs = append(outputs, generatedFile{kind: "Tailwind config", path: tailwindFile, content: content})
+		}
+		if jsModuleFile != "" {
+			switch ext := filepath.Ext(jsModuleFile); ext {
+			case ".ts", ".mts":
+				content, err := generator.RenderJSModule(matches, true)
+				if err != nil {
+					return fmt.Errorf("failed to generate TypeScript module: %w", err)
+				}
+				outputs = append(outputs, generatedFile{kind: "TypeScript module", path: jsModuleFile, content: content})
+			default:
+				declarationsFile := strings.TrimSuffix(jsModuleFile, ext) + ".d.ts"
+				if ext == ".mjs" {
+					declarationsFile = strings.TrimSuffix(jsModuleFile, ext) + ".d.mts"
+				}
+				content, err := generator.RenderJSModule(matches, false)
+				if err != nil {
+					return fmt.Errorf("failed to generate JavaScript module: %w", err)
+				}
+				declarations, err := generator.RenderTypeDeclarations(matches)
+				if err != nil {
+					return fmt.Errorf("failed to generate type declarations: %w", err)
+				}
+				outputs = append(outputs,
+					generatedFile{kind: "JavaScript module", path: jsModuleFile, content: content},
+					generatedFile{kind: "type declarations", path: declarationsFile, content: declarations})
+			}
+		}
+
+		// Native dark appearances come from one of the themes
+		if darkTheme == "" {
+			darkTheme = "dark"
+		}
+		darkValues := opts.Themes[darkTheme]
+		if (androidRes != "" || iosAssets != "") && darkTheme != "dark" && darkValues == nil {
+			return fmt.Errorf("--dark-theme %q is not a theme in the themes file", darkTheme)
+		}
+
+		if androidRes != "" {
+			files, err := generator.RenderAndroidResources(matches, darkValues)
+			if err != nil {
+				return fmt.Errorf("failed to generate Android resources: %w", err)
+			}
+			for _, file := range files {
+				outputs = append(outputs, generatedFile{kind: "Android resources", path: filepath.Join(androidRes, file.Path), content: file.Content, root: androidRes})
+			}
+		}
+		if iosAssets != "" {
+			files, err := generator.RenderIOSAssetCatalog(matches, darkValues)
+			if err != nil {
+				return fmt.Errorf("failed to generate iOS asset catalog: %w", err)
+			}
+			for _, file := range files {
+				outputs = append(outputs, generatedFile{kind: "iOS asset catalog", path: filepath.Join(iosAssets, file.Path), content: file.Content, root: iosAssets})
+			}
+		}
+
+		for _, paletteFile := range paletteFiles {
+			var content []byte
+			switch filepath.Ext(paletteFile) {
+			case ".gpl":
+				content = generator.RenderGPL(matches, baseFileName)
+			case ".ase":
+				content = generator.RenderASE(matches)
+			case ".csv":
+				content, err = generator.RenderPaletteCSV(matches, opts.Source)
+				if err != nil {
+					return fmt.Errorf("failed to generate palette: %w", err)
+				}
+			}
+			outputs = append(outputs, generatedFile{kind: "palette", path: paletteFile, content: content})
+		}
+
+		if reportFile != "" {
+			reportOpts := generator.ReportOptions{Source: filepath.Base(inputFile), SourceURL: reportSourceURL}
+			content, err := generator.RenderReport(matches, reportOpts)
+			if err != nil {
+				return err
+			}
+			outputs = append(outputs, generatedFile{kind: "report", path: reportFile, content: content})
+		}
+
+		if dryRun {
+			return printDryRun(inputFile, modifiedFile, matches, modifiedOpts, outputs)
+		}
+
+		for _, output := range outputs {
+			if err := os.MkdirAll(filepath.Dir(output.path), 0755); err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", output.kind, err)
+			}
+			if err := os.WriteFile(output.path, output.content, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", output.kind, err)
+			}
 		}
 
 		// Generate the modified file
-		err = generator.GenerateModifiedFile(inputFile, matches, modifiedFile)
-		if err != nil {
-			return fmt.Errorf("failed to generate modified file: %w", err)
+		if inPlace {
+			content, err := generator.RenderModifiedFile(inputFile, matches, inputFile, modifiedOpts)
+			if err != nil {
+				return fmt.Errorf("failed to generate modified file: %w", err)
+			}
+			if err := writeFileAtomic(inputFile, content, backup); err != nil {
+				return err
+			}
+		} else {
+			err = generator.GenerateModifiedFile(inputFile, matches, modifiedFile, modifiedOpts)
+			if err != nil {
+				return fmt.Errorf("failed to generate modified file: %w", err)
+			}
 		}
 
-		fmt.Printf("Found %d unique colors\n", len(matches))
+		if lock != nil {
+			if err := lock.Save(); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("Found %d unique colors\n", len(matches)+len(unmatched))
+		if tokensFile != "" {
+			fmt.Printf("Matched %d colors to tokens from %s, left %d unchanged\n", len(matches), tokensFile, len(unmatched))
+		}
 		if format != "" {
 			fmt.Printf("Converted all colors to %s format\n", format)
 		}
-		fmt.Printf("Generated variables file: %s\n", variablesFile)
-		fmt.Printf("Generated modified file: %s\n", modifiedFile)
+		if len(opts.Themes) > 0 {
+			fmt.Printf("Generated themes: %s\n", strings.Join(opts.Themes.Names(), ", "))
+		}
+		if namesFile != "" {
+			fmt.Printf("Added %d new colors to names file: %s\n", len(addedNames), namesFile)
+		}
+		reported := make(map[string]bool)
+		for _, output := range outputs {
+			switch {
+			case output.root == "":
+				fmt.Printf("Generated %s: %s\n", output.kind, output.path)
+			case !reported[output.root]:
+				reported[output.root] = true
+				fmt.Printf("Generated %s: %s\n", output.kind, output.root)
+			}
+		}
+		if inPlace {
+			fmt.Printf("Rewrote input file: %s\n", inputFile)
+			if backup {
+				fmt.Printf("Kept backup: %s.bak\n", inputFile)
+			}
+		} else {
+			fmt.Printf("Generated modified file: %s\n", modifiedFile)
+		}
+
+		// List the palette, as swatches in a color terminal
+		fmt.Println()
+		terminal.PrintSwatches(os.Stdout, matches, terminal.ColorSupport(os.Stdout))
 		return nil
 	},
 }
@@ -109,4 +470,95 @@ func init() {
 	Cmd.Flags().StringP("format", "f", "", "convert all colors to specified format: hex, rgb, or rgba")
 	Cmd.Flags().StringP("output-file", "o", "", "name for the output file (default: {filename}-with-variables.css)")
 	Cmd.Flags().StringP("output-variable-file", "v", "", "name for the output variables file (default: {filename}-variables.css)")
+	Cmd.Flags().BoolP("in-place", "i", false, "rewrite the input file itself instead of writing a -with-variables copy")
+	Cmd.Flags().Bool("backup", false, "with --in-place, keep the original input as {filename}.bak")
+	Cmd.Flags().Bool("force", false, "with --in-place, rewrite the input even if it has uncommitted git changes")
+	Cmd.Flags().Bool("dry-run", false, "print a diff of the modified file and a preview of the variables file without writing anything")
+	Cmd.Flags().StringSlice("variables-format", []string{"css"}, "variables to generate: css (custom properties), sass ($color-* in _<input>-colors.scss) or sass-css (both), plus dtcg for a design tokens JSON file, e.g. css,dtcg")
+	Cmd.Flags().String("sort", "appearance", "order of the variables file: appearance, hue, lightness, usage or name")
+	Cmd.Flags().Bool("group", false, "group the variables file into commented sections by hue (neutrals, reds, blues, ...) with alpha variants under their base color")
+	Cmd.Flags().String("annotate", "none", "comment on each declaration in the variables file: none, uses (usage count) or full (count, properties and first file:line)")
+	Cmd.Flags().String("scope", ":root", "selector the custom properties are declared on, e.g. :root, :host or .app-foo; theme selectors are scoped to it")
+	Cmd.Flags().String("layer", "", "wrap the custom properties in a cascade layer with this name, e.g. tokens")
+	Cmd.Flags().String("output-tokens-file", "", "name for the design tokens file written by --variables-format dtcg (default: {filename}-tokens.json)")
+	Cmd.Flags().Bool("fallback", false, "include the color as var() fallback: var(--color-x, #ff0000)")
+	Cmd.Flags().Bool("legacy-fallback", false, "keep a declaration with the literal color before each rewritten one for browsers without custom properties")
+	Cmd.Flags().String("tailwind", "", "also write a Tailwind theme extension to this path, e.g. tailwind.config.js (.ts for TypeScript, .mjs for an ES module)")
+	Cmd.Flags().String("tailwind-values", "var", "what Tailwind colors map to: var (var(--color-x)) or literal (the color value)")
+	Cmd.Flags().String("js-module", "", "also write the palette as an ES module to this path: colors.js (plus colors.d.ts) or colors.ts")
+	Cmd.Flags().String("android-res", "", "also write Android color resources to this res directory (values/colors.xml, values-night/colors.xml from the --dark-theme theme)")
+	Cmd.Flags().String("ios-assets", "", "also write an iOS asset catalog to this directory, e.g. Colors.xcassets, with dark appearances from the --dark-theme theme")
+	Cmd.Flags().String("dark-theme", "dark", "theme from --themes used for values-night on Android and the dark appearance on iOS")
+	Cmd.Flags().StringSlice("export-palette", nil, "also write the palette for design tools to these files: .gpl (GIMP, Inkscape), .ase (Adobe) or .csv")
+	Cmd.Flags().String("report", "", "also write a self-contained HTML report of the palette with swatches, formats, contrast and occurrences")
+	Cmd.Flags().String("report-source-url", "", "link report occurrences to the input file at this URL, e.g. on a code host; {line} is replaced by the line number, otherwise #L<line> is appended")
+	Cmd.Flags().String("scss-import", "use", "rule that loads the variables file in SCSS output: use (@use ... as *), import (@import) or none")
+	Cmd.Flags().String("naming", "value", "variable naming strategy: value, name (nearest color name), role (text, bg, border, shadow) or scale (blue-500)")
+	Cmd.Flags().String("name-template", "", "Go text/template for variable names, e.g. '--brand-{{.Name}}' (overrides --naming)")
+	Cmd.Flags().String("names", "", "names.json or names.yaml mapping colors to variable names; read and updated on every run")
+	Cmd.Flags().String("tokens", "", "DTCG or Tokens Studio JSON file; colors are named after matching tokens and colors without one are left unchanged")
+	Cmd.Flags().Float64("tolerance", 0, "with --tokens, also match tokens within this CIEDE2000 color difference (e.g. 2)")
+	Cmd.Flags().String("palette", "", "name colors after the swatches of a .gpl or .ase palette")
+	Cmd.Flags().Float64("snap-threshold", 0, "with --palette, snap colors within this CIEDE2000 color difference to the closest swatch (e.g. 2)")
+	Cmd.Flags().String("themes", "", "JSON file mapping theme names to per-variable values, emitted as [data-theme] blocks")
+}
+
+// generatedFile is a rendered file that create writes next to the modified
+// input, or previews with --dry-run.
+type generatedFile struct {
+	kind    string
+	path    string
+	content []byte
+	// root is the directory of a multi-file export, reported instead of
+	// every file in it.
+	root string
+}
+
+// parseVariablesFormats splits the --variables-format values into the
+// format of the variables file, "" when none is written, and whether a DTCG
+// tokens file is written.
+func parseVariablesFormats(formats []string) (string, bool, error) {
+	if len(formats) == 0 {
+		return "css", false, nil
+	}
+
+	variablesFormat := ""
+	dtcg := false
+	for _, format := range formats {
+		switch format {
+		case "dtcg":
+			dtcg = true
+		case "css", "sass", "sass-css":
+			if variablesFormat != "" && variablesFormat != format {
+				return "", false, fmt.Errorf("--variables-format accepts only one of css, sass and sass-css")
+			}
+			variablesFormat = format
+		default:
+			return "", false, fmt.Errorf("invalid variables format %q. Must be one of: css, sass, sass-css, dtcg", format)
+		}
+	}
+	return variablesFormat, dtcg, nil
+}
+
+// printUnmatched lists the colors that --tokens leaves unchanged.
+func printUnmatched(unmatched []colors.ColorMatch) {
+	if len(unmatched) == 0 {
+		return
+	}
+	fmt.Println("Colors without a matching token (left unchanged):")
+	for _, match := range unmatched {
+		fmt.Printf("  %s (line %d)\n", match.Original, match.Line)
+	}
+}
+
+// tailwindModule picks the Tailwind config flavor from its file extension.
+func tailwindModule(path string) string {
+	switch filepath.Ext(path) {
+	case ".ts", ".mts":
+		return "ts"
+	case ".mjs":
+		return "esm"
+	default:
+		return "cjs"
+	}
 }
@@ -1,8 +1,10 @@
 package create
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -29,6 +31,22 @@ func TestCreateCommand(t *testing.T) {
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 
+	themesFile := filepath.Join(tempDir, "themes.json")
+	err = os.WriteFile(themesFile, []byte(`{"dark": {
+  "--color-ff0000": "#aa0000",
+  "--color-rgb-0-255-0": "#00aa00",
+  "--color-rgba-0-0-255-0-5": "rgba(0, 0, 170, 0.5)"
+}}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create themes file: %v", err)
+	}
+
+	incompleteThemesFile := filepath.Join(tempDir, "incomplete-themes.json")
+	err = os.WriteFile(incompleteThemesFile, []byte(`{"dark": {"--color-ff0000": "#aa0000"}}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create themes file: %v", err)
+	}
+
 	tests := []struct {
 		name    string
 		args    []string
@@ -75,6 +93,88 @@ func TestCreateCommand(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "with fallbacks",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"fallback":        "true",
+				"legacy-fallback": "true",
+			},
+		},
+		{
+			name: "with themes",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"themes": themesFile,
+			},
+		},
+		{
+			name: "with incomplete themes",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"themes": incompleteThemesFile,
+			},
+			wantErr: true,
+		},
+		{
+			name: "with name naming",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"naming": "name",
+			},
+		},
+		{
+			name: "with role naming",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"naming": "role",
+			},
+		},
+		{
+			name: "with scale naming",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"naming": "scale",
+			},
+		},
+		{
+			name: "with name template",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"name-template": "--brand-{{.Index}}",
+			},
+		},
+		{
+			name: "with invalid name template",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"name-template": "brand {{.Index}}",
+			},
+			wantErr: true,
+		},
+		{
+			name: "with names file",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"names": filepath.Join(tempDir, "names.json"),
+			},
+		},
+		{
+			name: "invalid naming",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"naming": "invalid",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid scss import for css input",
+			args: []string{inputFile},
+			flags: map[string]string{
+				"scss-import": "require",
+			},
+			wantErr: true,
+		},
 		{
 			name:    "non-existent file",
 			args:    []string{"non-existent.css"},
@@ -87,6 +187,13 @@ func TestCreateCommand(t *testing.T) {
 			cmd := &cobra.Command{}
 			cmd.Flags().StringP("output-dir", "d", "", "")
 			cmd.Flags().StringP("format", "f", "", "")
+			cmd.Flags().String("themes", "", "")
+			cmd.Flags().Bool("fallback", false, "")
+			cmd.Flags().Bool("legacy-fallback", false, "")
+			cmd.Flags().String("naming", "", "")
+			cmd.Flags().String("name-template", "", "")
+			cmd.Flags().String("names", "", "")
+			cmd.Flags().String("scss-import", "", "")
 
 			for name, value := range tt.flags {
 				err := cmd.Flags().Set(name, value)
@@ -125,3 +232,670 @@ func TestCreateCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateCommand_NamesFile(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "input.css")
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	namesFile := filepath.Join(tempDir, "names.json")
+	err = os.WriteFile(namesFile, []byte(`{"#ff0000": "--color-brand"}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create names file: %v", err)
+	}
+
+	run := func() {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("names", namesFile, "")
+		if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+			t.Fatalf("RunE() error = %v", err)
+		}
+	}
+
+	run()
+	content, err := os.ReadFile(filepath.Join(tempDir, "input-with-variables.css"))
+	if err != nil {
+		t.Fatalf("Failed to read modified file: %v", err)
+	}
+	if !strings.Contains(string(content), "var(--color-brand)") {
+		t.Errorf("Modified file should use the locked name, got:\n%s", content)
+	}
+
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; background: #00ff00; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to update test file: %v", err)
+	}
+	run()
+
+	names, err := os.ReadFile(namesFile)
+	if err != nil {
+		t.Fatalf("Failed to read names file: %v", err)
+	}
+	expected := `{
+  "#ff0000": "--color-brand",
+  "#00ff00": "--color-00ff00"
+}
+`
+	if string(names) != expected {
+		t.Errorf("Names file = %s, want %s", names, expected)
+	}
+}
+
+func TestCreateCommand_InPlace(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.css")
+	original := ".a { color: #ff0000; }\n"
+	err = os.WriteFile(inputFile, []byte(original), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("in-place", true, "")
+	cmd.Flags().Bool("backup", true, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	content, err := os.ReadFile(inputFile)
+	if err != nil {
+		t.Fatalf("Failed to read rewritten file: %v", err)
+	}
+	if string(content) != ".a { color: var(--color-ff0000); }\n" {
+		t.Errorf("Rewritten file = %q", content)
+	}
+
+	backup, err := os.ReadFile(inputFile + ".bak")
+	if err != nil || string(backup) != original {
+		t.Errorf("Backup file = %q, %v, want original content", backup, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, "style-with-variables.css")); !os.IsNotExist(err) {
+		t.Errorf("In-place mode should not write a -with-variables copy")
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "style-variables.css")); err != nil {
+		t.Errorf("Variables file was not created: %v", err)
+	}
+
+	cmd = &cobra.Command{}
+	cmd.Flags().Bool("backup", true, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err == nil {
+		t.Error("RunE() with --backup but without --in-place expected error")
+	}
+}
+
+func TestCreateCommand_DryRun(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.css")
+	err = os.WriteFile(inputFile, []byte(".a {\n  color: #ff0000;\n}\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() {
+		os.Stdout = oldStdout
+	}()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("dry-run", true, "")
+	cmd.Flags().String("names", filepath.Join(tempDir, "names.json"), "")
+	err = Cmd.RunE(cmd, []string{inputFile})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	if err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	expectedParts := []string{
+		"--- " + inputFile,
+		"+++ " + filepath.Join(tempDir, "style-with-variables.css"),
+		"-  color: #ff0000;",
+		"+  color: var(--color-ff0000);",
+		"+++ " + filepath.Join(tempDir, "style-variables.css"),
+		"+  --color-ff0000: #ff0000;",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(buf.String(), part) {
+			t.Errorf("Expected output to contain %q, got:\n%s", part, buf.String())
+		}
+	}
+
+	entries, _ := os.ReadDir(tempDir)
+	if len(entries) != 1 {
+		t.Errorf("Dry run wrote files: %d entries in output directory, want 1", len(entries))
+	}
+}
+
+func TestCreateCommand_PlainList(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.css")
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() {
+		os.Stdout = oldStdout
+	}()
+
+	err = Cmd.RunE(&cobra.Command{}, []string{inputFile})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	if err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	// Piped output lists the palette without swatches
+	if !strings.HasSuffix(buf.String(), "\n\n  --color-ff0000  #ff0000\n") {
+		t.Errorf("Expected output to end with the plain palette list, got:\n%s", buf.String())
+	}
+}
+
+func TestCreateCommand_SassVariables(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.scss")
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringSlice("variables-format", []string{"sass"}, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	variables, err := os.ReadFile(filepath.Join(tempDir, "_style-colors.scss"))
+	if err != nil {
+		t.Fatalf("Failed to read variables file: %v", err)
+	}
+	if string(variables) != "$color-ff0000: #ff0000 !default;\n" {
+		t.Errorf("Variables file = %q", variables)
+	}
+
+	modified, err := os.ReadFile(filepath.Join(tempDir, "style-with-variables.scss"))
+	if err != nil {
+		t.Fatalf("Failed to read modified file: %v", err)
+	}
+	if string(modified) != "@use 'style-colors' as *;\n\n.a { color: $color-ff0000; }\n" {
+		t.Errorf("Modified file = %q", modified)
+	}
+
+	// A second stylesheet in the same directory gets its own partial
+	otherFile := filepath.Join(tempDir, "other.scss")
+	err = os.WriteFile(otherFile, []byte(".b { color: #0000ff; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := Cmd.RunE(cmd, []string{otherFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+	variables, err = os.ReadFile(filepath.Join(tempDir, "_style-colors.scss"))
+	if err != nil || string(variables) != "$color-ff0000: #ff0000 !default;\n" {
+		t.Errorf("Variables file of the first stylesheet = %q, %v", variables, err)
+	}
+
+	cssFile := filepath.Join(tempDir, "style.css")
+	err = os.WriteFile(cssFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := Cmd.RunE(cmd, []string{cssFile}); err == nil {
+		t.Error("RunE() with sass variables for a .css input expected error")
+	}
+}
+
+func TestCreateCommand_DTCGOnly(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.scss")
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringSlice("variables-format", []string{"dtcg"}, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	tokens, err := os.ReadFile(filepath.Join(tempDir, "style-tokens.json"))
+	if err != nil {
+		t.Fatalf("Failed to read tokens file: %v", err)
+	}
+	if !strings.Contains(string(tokens), `"ff0000": {`) {
+		t.Errorf("Tokens file = %q", tokens)
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, "style-variables.scss")); !os.IsNotExist(err) {
+		t.Errorf("Variables file written with --variables-format dtcg only")
+	}
+
+	modified, err := os.ReadFile(filepath.Join(tempDir, "style-with-variables.scss"))
+	if err != nil {
+		t.Fatalf("Failed to read modified file: %v", err)
+	}
+	if string(modified) != ".a { color: var(--color-ff0000); }\n" {
+		t.Errorf("Modified file = %q", modified)
+	}
+}
+
+func TestParseVariablesFormats(t *testing.T) {
+	tests := []struct {
+		formats    []string
+		wantFormat string
+		wantDTCG   bool
+		wantErr    bool
+	}{
+		{nil, "css", false, false},
+		{[]string{"css"}, "css", false, false},
+		{[]string{"sass-css", "dtcg"}, "sass-css", true, false},
+		{[]string{"dtcg"}, "", true, false},
+		{[]string{"css", "sass"}, "", false, true},
+		{[]string{"json"}, "", false, true},
+	}
+
+	for _, tt := range tests {
+		format, dtcg, err := parseVariablesFormats(tt.formats)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseVariablesFormats(%v) error = %v, wantErr %v", tt.formats, err, tt.wantErr)
+			continue
+		}
+		if format != tt.wantFormat || dtcg != tt.wantDTCG {
+			t.Errorf("parseVariablesFormats(%v) = %q, %v, want %q, %v", tt.formats, format, dtcg, tt.wantFormat, tt.wantDTCG)
+		}
+	}
+}
+
+func TestCreateCommand_Tokens(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.css")
+	err = os.WriteFile(inputFile, []byte(".a { color: #fe0000; background: #00ff00; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	tokensFile := filepath.Join(tempDir, "tokens.json")
+	err = os.WriteFile(tokensFile, []byte(`{"color": {"$type": "color", "brand": {"$value": "#ff0000"}}}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create tokens file: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("tokens", tokensFile, "")
+	cmd.Flags().Float64("tolerance", 2, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	variables, err := os.ReadFile(filepath.Join(tempDir, "style-variables.css"))
+	if err != nil {
+		t.Fatalf("Failed to read variables file: %v", err)
+	}
+	if string(variables) != ":root {\n  --color-brand: #ff0000;\n}\n" {
+		t.Errorf("Variables file = %q", variables)
+	}
+
+	modified, err := os.ReadFile(filepath.Join(tempDir, "style-with-variables.css"))
+	if err != nil {
+		t.Fatalf("Failed to read modified file: %v", err)
+	}
+	if string(modified) != ".a { color: var(--color-brand); background: #00ff00; }\n" {
+		t.Errorf("Modified file = %q", modified)
+	}
+
+	cmd.Flags().String("names", filepath.Join(tempDir, "names.json"), "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err == nil {
+		t.Error("RunE() with --tokens and --names expected error")
+	}
+}
+
+func TestCreateCommand_Tailwind(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.css")
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	configFile := filepath.Join(tempDir, "tailwind.config.ts")
+	cmd := &cobra.Command{}
+	cmd.Flags().String("tailwind", configFile, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	config, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("Failed to read Tailwind config: %v", err)
+	}
+	if !strings.Contains(string(config), "'ff0000': 'var(--color-ff0000)',") || !strings.Contains(string(config), "satisfies Partial<Config>") {
+		t.Errorf("Tailwind config = %q", config)
+	}
+
+	cmd.Flags().StringSlice("variables-format", []string{"dtcg"}, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err == nil {
+		t.Error("RunE() with var Tailwind values and no custom properties expected error")
+	}
+}
+
+func TestCreateCommand_JSModule(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.css")
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("js-module", filepath.Join(tempDir, "colors.js"), "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	for _, name := range []string{"colors.js", "colors.d.ts"} {
+		content, err := os.ReadFile(filepath.Join(tempDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if !strings.Contains(string(content), "'--color-ff0000'") {
+			t.Errorf("%s = %q", name, content)
+		}
+	}
+}
+
+func TestCreateCommand_PlatformExports(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.css")
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	themesFile := filepath.Join(tempDir, "themes.json")
+	err = os.WriteFile(themesFile, []byte(`{"dark": {"--color-ff0000": "#aa0000"}}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create themes file: %v", err)
+	}
+
+	resDir := filepath.Join(tempDir, "res")
+	assetsDir := filepath.Join(tempDir, "Colors.xcassets")
+	cmd := &cobra.Command{}
+	cmd.Flags().String("themes", themesFile, "")
+	cmd.Flags().String("android-res", resDir, "")
+	cmd.Flags().String("ios-assets", assetsDir, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	night, err := os.ReadFile(filepath.Join(resDir, "values-night", "colors.xml"))
+	if err != nil {
+		t.Fatalf("Failed to read night colors: %v", err)
+	}
+	if !strings.Contains(string(night), `<color name="ff0000">#FFAA0000</color>`) {
+		t.Errorf("values-night/colors.xml = %q", night)
+	}
+
+	for _, path := range []string{
+		filepath.Join(resDir, "values", "colors.xml"),
+		filepath.Join(assetsDir, "Contents.json"),
+		filepath.Join(assetsDir, "ff0000.colorset", "Contents.json"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s: %v", path, err)
+		}
+	}
+
+	// Dark appearances can come from a theme with another name
+	err = os.WriteFile(themesFile, []byte(`{"night": {"--color-ff0000": "#bb0000"}}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create themes file: %v", err)
+	}
+	cmd.Flags().String("dark-theme", "night", "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+	night, err = os.ReadFile(filepath.Join(resDir, "values-night", "colors.xml"))
+	if err != nil || !strings.Contains(string(night), `<color name="ff0000">#FFBB0000</color>`) {
+		t.Errorf("values-night/colors.xml = %q, %v", night, err)
+	}
+
+	cmd.Flags().Set("dark-theme", "dusk")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err == nil {
+		t.Error("RunE() with an unknown --dark-theme expected error")
+	}
+}
+
+func TestCreateCommand_ExportPalette(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.css")
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	gplFile := filepath.Join(tempDir, "palette.gpl")
+	aseFile := filepath.Join(tempDir, "palette.ase")
+	csvFile := filepath.Join(tempDir, "palette.csv")
+	cmd := &cobra.Command{}
+	cmd.Flags().StringSlice("export-palette", []string{gplFile, aseFile, csvFile}, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	gpl, err := os.ReadFile(gplFile)
+	if err != nil {
+		t.Fatalf("Failed to read GPL palette: %v", err)
+	}
+	if !strings.Contains(string(gpl), "255   0   0\tff0000") {
+		t.Errorf("GPL palette = %q", gpl)
+	}
+	ase, err := os.ReadFile(aseFile)
+	if err != nil || !strings.HasPrefix(string(ase), "ASEF") {
+		t.Errorf("ASE palette = %q, error = %v", ase, err)
+	}
+	csv, err := os.ReadFile(csvFile)
+	if err != nil || !strings.Contains(string(csv), "ff0000,#ff0000,") || !strings.HasSuffix(string(csv), ",style.css:1\n") {
+		t.Errorf("CSV palette = %q, error = %v", csv, err)
+	}
+
+	cmd = &cobra.Command{}
+	cmd.Flags().StringSlice("export-palette", []string{filepath.Join(tempDir, "palette.aco")}, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err == nil {
+		t.Error("RunE() with an unsupported palette file expected error")
+	}
+}
+
+func TestCreateCommand_Palette(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.css")
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; border-color: #fe0101; background: #00ff00; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	paletteFile := filepath.Join(tempDir, "brand.gpl")
+	err = os.WriteFile(paletteFile, []byte("GIMP Palette\nName: Brand\n#\n255 0 0\tBrand Red\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create palette file: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("palette", paletteFile, "")
+	cmd.Flags().Float64("snap-threshold", 2, "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	variables, err := os.ReadFile(filepath.Join(tempDir, "style-variables.css"))
+	if err != nil {
+		t.Fatalf("Failed to read variables file: %v", err)
+	}
+	want := ":root {\n  --color-brand-red: #ff0000;\n  --color-00ff00: #00ff00;\n}\n"
+	if string(variables) != want {
+		t.Errorf("Variables file = %q, want %q", variables, want)
+	}
+
+	modified, err := os.ReadFile(filepath.Join(tempDir, "style-with-variables.css"))
+	if err != nil {
+		t.Fatalf("Failed to read modified file: %v", err)
+	}
+	want = ".a { color: var(--color-brand-red); border-color: var(--color-brand-red); background: var(--color-00ff00); }\n"
+	if string(modified) != want {
+		t.Errorf("Modified file = %q, want %q", modified, want)
+	}
+}
+
+func TestCreateCommand_Report(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "src", "style.css")
+	if err := os.MkdirAll(filepath.Dir(inputFile), 0755); err != nil {
+		t.Fatalf("Failed to create source directory: %v", err)
+	}
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	reportFile := filepath.Join(tempDir, "docs", "palette.html")
+	cmd := &cobra.Command{}
+	cmd.Flags().String("report", reportFile, "")
+	cmd.Flags().String("report-source-url", "", "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	report, err := os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	if !strings.Contains(string(report), "<li>style.css:1 color</li>") {
+		t.Errorf("Report does not list the occurrence as plain text:\n%s", report)
+	}
+
+	cmd.Flags().Set("report-source-url", "https://github.com/org/repo/blob/main/src/style.css")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	report, err = os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	if !strings.Contains(string(report), `<a href="https://github.com/org/repo/blob/main/src/style.css#L1">style.css:1</a> color`) {
+		t.Errorf("Report does not link the occurrence:\n%s", report)
+	}
+}
+
+func TestCreateCommand_Scope(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "create-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "widget.css")
+	err = os.WriteFile(inputFile, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("scope", ":host", "")
+	cmd.Flags().String("layer", "tokens", "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "widget-variables.css"))
+	if err != nil {
+		t.Fatalf("Failed to read variables file: %v", err)
+	}
+	expected := "@layer tokens {\n  :host {\n    --color-ff0000: #ff0000;\n  }\n}\n"
+	if string(content) != expected {
+		t.Errorf("Variables file = %q, want %q", string(content), expected)
+	}
+
+	cmd = &cobra.Command{}
+	cmd.Flags().String("scope", ".a, .b", "")
+	if err := Cmd.RunE(cmd, []string{inputFile}); err == nil {
+		t.Error("RunE() with a selector list as scope expected error")
+	}
+}
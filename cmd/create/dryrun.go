@@ -0,0 +1,47 @@
+package create
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"css-color-variable-creator/pkg/colors"
+	"css-color-variable-creator/pkg/diff"
+	"css-color-variable-creator/pkg/generator"
+	"css-color-variable-creator/pkg/terminal"
+)
+
+// printDryRun prints what create would write: a unified diff from the
+// input to the modified file and the full content of every other output.
+func printDryRun(inputFile, modifiedFile string, matches []colors.ColorMatch, modifiedOpts generator.ModifiedOptions, outputs []generatedFile) error {
+	original, err := os.ReadFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	modified, err := generator.RenderModifiedFile(inputFile, matches, modifiedFile, modifiedOpts)
+	if err != nil {
+		return fmt.Errorf("failed to generate modified file: %w", err)
+	}
+
+	color := terminal.ColorEnabled(os.Stdout)
+	changes := diff.Unified(inputFile, modifiedFile, original, modified)
+	if color {
+		changes = diff.Colorize(changes)
+	}
+
+	fmt.Printf("Found %d unique colors (dry run, no files written)\n\n", len(matches))
+	fmt.Print(changes)
+	for _, output := range outputs {
+		if bytes.IndexByte(output.content, 0) >= 0 {
+			fmt.Printf("Binary file %s (%d bytes)\n", output.path, len(output.content))
+			continue
+		}
+		preview := diff.Unified("/dev/null", output.path, nil, output.content)
+		if color {
+			preview = diff.Colorize(preview)
+		}
+		fmt.Print(preview)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package create
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// checkGitClean returns an error if path has uncommitted changes in its
+// git work tree. Files outside a work tree, or systems without git, pass;
+// other git failures, like a corrupt repository, are errors.
+func checkGitClean(path string) error {
+	dir := filepath.Dir(path)
+	inside, stderr, err := runGit(dir, "rev-parse", "--is-inside-work-tree")
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && strings.Contains(stderr, "not a git repository") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check git status: %w: %s", err, stderr)
+	}
+	if inside != "true" {
+		return nil
+	}
+
+	status, stderr, err := runGit(dir, "status", "--porcelain", "--", filepath.Base(path))
+	if err != nil {
+		return fmt.Errorf("failed to check git status: %w: %s", err, stderr)
+	}
+	if status != "" {
+		return fmt.Errorf("refusing to rewrite %s: it has uncommitted changes (use --force to override)", path)
+	}
+	return nil
+}
+
+// runGit runs git in dir and returns its trimmed output. Messages are not
+// translated so that they can be matched.
+func runGit(dir string, args ...string) (string, string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return strings.TrimSpace(stdout.String()), strings.TrimSpace(stderr.String()), err
+}
+
+// writeFileAtomic replaces path with content by writing a temporary file
+// next to it and renaming it over the original, so readers never see a
+// partially written file. With backup the original is kept as path.bak.
+func writeFileAtomic(path string, content []byte, backup bool) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat input file: %w", err)
+	}
+
+	if backup {
+		original, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read input file: %w", err)
+		}
+		if err := os.WriteFile(path+".bak", original, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write backup file: %w", err)
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace input file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package create
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "inplace-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	if err := writeFileAtomic(path, []byte("new"), true); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	if string(content) != "new" {
+		t.Errorf("file content = %q, want %q", content, "new")
+	}
+	backup, _ := os.ReadFile(path + ".bak")
+	if string(backup) != "old" {
+		t.Errorf("backup content = %q, want %q", backup, "old")
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+
+	entries, _ := os.ReadDir(tempDir)
+	if len(entries) != 2 {
+		t.Errorf("directory has %d entries, want only the file and its backup", len(entries))
+	}
+}
+
+func TestCheckGitClean(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	tempDir, err := os.MkdirTemp("", "inplace-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(path, []byte(".a { color: #fff; }\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	if err := checkGitClean(path); err != nil {
+		t.Errorf("checkGitClean() outside a repository error = %v", err)
+	}
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = tempDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+
+	err = checkGitClean(path)
+	if err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Errorf("checkGitClean() for untracked file error = %v, want uncommitted changes", err)
+	}
+
+	git("add", "style.css")
+	git("commit", "-q", "-m", "init")
+	if err := checkGitClean(path); err != nil {
+		t.Errorf("checkGitClean() for committed file error = %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(".a { color: #000; }\n"), 0644); err != nil {
+		t.Fatalf("Failed to modify test file: %v", err)
+	}
+	if err := checkGitClean(path); err == nil {
+		t.Error("checkGitClean() for modified file expected error")
+	}
+	// A broken repository must not turn the check off
+	if err := os.WriteFile(filepath.Join(tempDir, ".git", "index"), []byte("garbage"), 0644); err != nil {
+		t.Fatalf("Failed to corrupt index: %v", err)
+	}
+	err = checkGitClean(path)
+	if err == nil || !strings.Contains(err.Error(), "failed to check git status") {
+		t.Errorf("checkGitClean() for corrupt repository error = %v, want git failure", err)
+	}
+}
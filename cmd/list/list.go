@@ -0,0 +1,46 @@
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"css-color-variable-creator/pkg/colors"
+	"css-color-variable-creator/pkg/terminal"
+
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "list [input-file]",
+	Short: "List the colors of a CSS/SCSS file",
+	Long: `List command scans a CSS or SCSS file and prints every color with the
+variable name the --naming strategy gives it. Names from create's --names
+file or --name-template are not applied. In a color terminal each color is
+shown as a swatch.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		naming, _ := cmd.Flags().GetString("naming")
+
+		matches, err := colors.ScanFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to scan file: %w", err)
+		}
+
+		if len(matches) == 0 {
+			fmt.Println("No colors found in the input file")
+			return nil
+		}
+
+		if _, err := colors.ApplyNaming(matches, naming); err != nil {
+			return err
+		}
+		colors.EnsureUniqueNames(matches)
+
+		terminal.PrintSwatches(os.Stdout, matches, terminal.ColorSupport(os.Stdout))
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().String("naming", "value", "variable naming strategy: value, name (nearest color name), role (text, bg, border, shadow) or scale (blue-500)")
+}
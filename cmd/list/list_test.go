@@ -0,0 +1,49 @@
+package list
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestListCommand(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "list-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputFile := filepath.Join(tempDir, "style.css")
+	err = os.WriteFile(inputFile, []byte(".a {\n  color: #ff0000;\n  background: rgba(0, 0, 255, 0.5);\n}\n.b {\n  color: #f00;\n}\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() {
+		os.Stdout = oldStdout
+	}()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("naming", "name", "")
+	err = Cmd.RunE(cmd, []string{inputFile})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	if err != nil {
+		t.Fatalf("RunE() error = %v", err)
+	}
+
+	// Output is a pipe, so no swatches are printed
+	expected := "  --color-red       #ff0000\n  --color-blue-a50  rgba(0, 0, 255, 0.5)\n"
+	if buf.String() != expected {
+		t.Errorf("Output = %q, want %q", buf.String(), expected)
+	}
+}
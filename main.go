@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"css-color-variable-creator/cmd/create"
+	"css-color-variable-creator/cmd/list"
 
 	"github.com/spf13/cobra"
 )
@@ -23,6 +24,7 @@ create and manage CSS color variables in your stylesheets.`,
 
 func init() {
 	rootCmd.AddCommand(create.Cmd)
+	rootCmd.AddCommand(list.Cmd)
 }
 
 func main() {
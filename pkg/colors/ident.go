@@ -0,0 +1,85 @@
+package colors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// customPropertyRegex matches a custom property name: "--" followed by
+	// ident code points (letters, digits, "-", "_" and non-ASCII).
+	customPropertyRegex = regexp.MustCompile(`^--[a-zA-Z0-9_\-\x{80}-\x{10FFFF}]+$`)
+	invalidIdentRegex   = regexp.MustCompile(`[^a-zA-Z0-9_\-\x{80}-\x{10FFFF}]+`)
+	hyphenRunRegex      = regexp.MustCompile(`-{2,}`)
+	// identRegex matches a CSS or Sass identifier, which cannot start with a
+	// digit or a hyphen followed by a digit.
+	identRegex = regexp.MustCompile(`^(--|-?[a-zA-Z_\x{80}-\x{10FFFF}])[a-zA-Z0-9_\-\x{80}-\x{10FFFF}]*$`)
+)
+
+func IsCustomProperty(name string) bool {
+	return customPropertyRegex.MatchString(name)
+}
+
+// IsIdentifier reports whether name is a valid CSS identifier, and so a
+// valid Sass variable name without the "$".
+func IsIdentifier(name string) bool {
+	return identRegex.MatchString(name)
+}
+
+// SanitizeName turns name into a valid custom property name without
+// leading, trailing or repeated hyphens after the "--" prefix, e.g.
+// "--color-rgb-255-0-0-" becomes "--color-rgb-255-0-0".
+func SanitizeName(name string) string {
+	body := strings.TrimPrefix(name, "--")
+	body = invalidIdentRegex.ReplaceAllString(body, "-")
+	body = hyphenRunRegex.ReplaceAllString(body, "-")
+	body = strings.Trim(body, "-")
+	if body == "" {
+		body = "color"
+	}
+	return "--" + body
+}
+
+// EnsureUniqueNames sanitizes invalid variable names and makes sure
+// different colors never share a name. Valid names, such as
+// "--ds-text--1" from a name template, are kept as they are. Matches with
+// the same color value, like "#FFF" and "#fff" or colors snapped to one
+// swatch, may keep sharing one. Conflicts are resolved in file order: the
+// first color keeps the name and later ones are numbered ("--color-red-2").
+// It returns a line for every name it changed.
+func EnsureUniqueNames(matches []ColorMatch) []string {
+	var report []string
+	owners := make(map[string]int)
+
+	for i := range matches {
+		name := matches[i].Variable
+		if !IsCustomProperty(name) {
+			name = SanitizeName(name)
+			report = append(report, fmt.Sprintf("renamed %s to %s for %s: not a valid custom property name",
+				matches[i].Variable, name, matches[i].Original))
+		}
+
+		base := name
+		key := rgbaKey(matches[i].Value)
+		for n := 2; ; n++ {
+			owner, taken := owners[name]
+			if !taken || rgbaKey(matches[owner].Value) == key {
+				break
+			}
+			name = fmt.Sprintf("%s-%d", base, n)
+		}
+		if name != base {
+			owner := owners[base]
+			report = append(report, fmt.Sprintf("renamed %s to %s for %s: %s already names %s",
+				base, name, matches[i].Original, base, matches[owner].Original))
+		}
+
+		if _, taken := owners[name]; !taken {
+			owners[name] = i
+		}
+		matches[i].Variable = name
+	}
+
+	return report
+}
@@ -0,0 +1,106 @@
+package colors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsCustomProperty(t *testing.T) {
+	tests := map[string]bool{
+		"--color-ff0000":  true,
+		"--brand_primary": true,
+		"--färbe":         true,
+		"--":              false,
+		"-color":          false,
+		"color":           false,
+		"--color red":     false,
+		"--color.red":     false,
+	}
+
+	for name, want := range tests {
+		if got := IsCustomProperty(name); got != want {
+			t.Errorf("IsCustomProperty(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestIsIdentifier(t *testing.T) {
+	tests := map[string]bool{
+		"color-ff0000": true,
+		"_brand":       true,
+		"-webkit-red":  true,
+		"--x":          true,
+		"färbe":        true,
+		"1x":           false,
+		"-1x":          false,
+		"":             false,
+		"color red":    false,
+	}
+
+	for name, want := range tests {
+		if got := IsIdentifier(name); got != want {
+			t.Errorf("IsIdentifier(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestSanitizeName(t *testing.T) {
+	tests := map[string]string{
+		"--color-ff0000":       "--color-ff0000",
+		"--color-rgb-255-0-0-": "--color-rgb-255-0-0",
+		"--color--red":         "--color-red",
+		"--color.red 2":        "--color-red-2",
+		"color-red":            "--color-red",
+		"--":                   "--color",
+		"--färbe-rot":          "--färbe-rot",
+	}
+
+	for name, want := range tests {
+		got := SanitizeName(name)
+		if got != want {
+			t.Errorf("SanitizeName(%q) = %q, want %q", name, got, want)
+		}
+		if !IsCustomProperty(got) {
+			t.Errorf("SanitizeName(%q) = %q is not a valid custom property", name, got)
+		}
+	}
+}
+
+func TestEnsureUniqueNames(t *testing.T) {
+	matches := []ColorMatch{
+		{Original: "#ff0000", Variable: "--color-red", Value: "#ff0000"},
+		{Original: "#fe0000", Variable: "--color-red", Value: "#fe0000"},
+		{Original: "#FF0000", Variable: "--color-red", Value: "#FF0000"},
+		{Original: "#fd0000", Variable: "--color-red", Value: "#fd0000"},
+		{Original: "rgb(0, 0, 255)", Variable: "--color-rgb(0,0,255)", Value: "rgb(0, 0, 255)"},
+		{Original: "#00f", Variable: "--color-rgb-0-0-255", Value: "#00f"},
+		{Original: "#0f0", Variable: "--ds-text--1", Value: "#0f0"},
+	}
+
+	report := EnsureUniqueNames(matches)
+
+	want := []string{
+		"--color-red",
+		"--color-red-2",
+		"--color-red",
+		"--color-red-3",
+		"--color-rgb-0-0-255",
+		"--color-rgb-0-0-255",
+		"--ds-text--1",
+	}
+	for i := range want {
+		if matches[i].Variable != want[i] {
+			t.Errorf("%s Variable = %q, want %q", matches[i].Original, matches[i].Variable, want[i])
+		}
+	}
+
+	if len(report) != 3 {
+		t.Fatalf("EnsureUniqueNames() reported %d changes, want 3: %q", len(report), report)
+	}
+	if !strings.Contains(report[0], "--color-red-2 for #fe0000") || !strings.Contains(report[0], "already names #ff0000") {
+		t.Errorf("report[0] = %q, want collision of #fe0000 with #ff0000", report[0])
+	}
+	if !strings.Contains(report[2], "not a valid custom property name") {
+		t.Errorf("report[2] = %q, want invalid name note", report[2])
+	}
+}
@@ -0,0 +1,231 @@
+package colors
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// NameLock is a color to variable name mapping that keeps names stable
+// across runs. Entries keep the order of the file so that new colors are
+// appended instead of reshuffling existing ones.
+type NameLock struct {
+	path   string
+	colors []string
+	names  map[string]string
+}
+
+// LoadNameLock reads a names.json or names.yaml file. A missing file yields
+// an empty lock that will be created on Save.
+func LoadNameLock(path string) (*NameLock, error) {
+	lock := &NameLock{path: path, names: make(map[string]string)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read names file: %w", err)
+	}
+
+	if isYAML(path) {
+		err = lock.parseYAML(data)
+	} else {
+		err = lock.parseJSON(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse names file: %w", err)
+	}
+
+	// Spellings of one color may share a name, different colors may not:
+	// the variable would silently take the value of the first one
+	owners := make(map[string]string, len(lock.colors))
+	for _, color := range lock.colors {
+		name := lock.names[color]
+		if !IsCustomProperty(name) {
+			return nil, fmt.Errorf("invalid variable name %q for %s in %s", name, color, path)
+		}
+		if owner, ok := owners[name]; ok && rgbaKey(owner) != rgbaKey(color) {
+			return nil, fmt.Errorf("colors %s and %s both map to %s in %s", owner, color, name, path)
+		}
+		if _, ok := owners[name]; !ok {
+			owners[name] = color
+		}
+	}
+
+	return lock, nil
+}
+
+// Apply renames matches that have a locked name and adds the names of all
+// other matches to the lock, numbering them if they clash with a name of a
+// different color. It returns the colors that were added.
+func (l *NameLock) Apply(matches []ColorMatch) []string {
+	owners := make(map[string]string, len(l.names))
+	for color, name := range l.names {
+		owners[name] = rgbaKey(color)
+	}
+
+	var added []string
+	for i := range matches {
+		if name, ok := l.names[matches[i].Original]; ok {
+			matches[i].Variable = name
+			continue
+		}
+
+		key := rgbaKey(matches[i].Value)
+		name := matches[i].Variable
+		for n := 2; owners[name] != "" && owners[name] != key; n++ {
+			name = fmt.Sprintf("%s-%d", matches[i].Variable, n)
+		}
+
+		owners[name] = key
+		l.add(matches[i].Original, name)
+		matches[i].Variable = name
+		added = append(added, matches[i].Original)
+	}
+
+	return added
+}
+
+func (l *NameLock) Save() error {
+	var buf bytes.Buffer
+	if isYAML(l.path) {
+		for _, color := range l.colors {
+			fmt.Fprintf(&buf, "%s: %s\n", strconv.Quote(color), strconv.Quote(l.names[color]))
+		}
+	} else {
+		buf.WriteString("{\n")
+		for i, color := range l.colors {
+			key, _ := json.Marshal(color)
+			value, _ := json.Marshal(l.names[color])
+			buf.WriteString(fmt.Sprintf("  %s: %s", key, value))
+			if i < len(l.colors)-1 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString("}\n")
+	}
+
+	if err := os.WriteFile(l.path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write names file: %w", err)
+	}
+	return nil
+}
+
+func (l *NameLock) add(color, name string) {
+	if _, ok := l.names[color]; !ok {
+		l.colors = append(l.colors, color)
+	}
+	l.names[color] = name
+}
+
+func (l *NameLock) parseJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return fmt.Errorf("expected a JSON object")
+	}
+
+	for decoder.More() {
+		var color, name string
+		if token, err = decoder.Token(); err != nil {
+			return err
+		}
+		color = token.(string)
+		if err := decoder.Decode(&name); err != nil {
+			return fmt.Errorf("value for %s: %w", color, err)
+		}
+		l.add(color, name)
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+// parseYAML reads the flat `"color": "name"` mapping written by Save.
+// Quoting is optional for keys and values that need no escaping. Single
+// quoted scalars escape a quote by doubling it, as in YAML.
+func (l *NameLock) parseYAML(data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || line == "---" {
+			continue
+		}
+
+		color, rest, err := yamlScalar(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		rest = strings.TrimSpace(rest)
+		if !strings.HasPrefix(rest, ":") {
+			return fmt.Errorf("line %d: expected \"color: name\"", lineNum)
+		}
+		name, _, err := yamlScalar(strings.TrimSpace(rest[1:]))
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		l.add(color, name)
+	}
+	return scanner.Err()
+}
+
+func yamlScalar(s string) (value, rest string, err error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		end := 1
+		for end < len(s) && s[end] != '"' {
+			if s[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(s) {
+			return "", "", fmt.Errorf("unterminated string %s", s)
+		}
+		value, err = strconv.Unquote(s[:end+1])
+		return value, s[end+1:], err
+	case strings.HasPrefix(s, "'"):
+		// In single-quoted scalars a quote is escaped by doubling it
+		var b strings.Builder
+		for i := 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				b.WriteByte(s[i])
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return b.String(), s[i+1:], nil
+		}
+		return "", "", fmt.Errorf("unterminated string %s", s)
+	default:
+		if i := strings.Index(s, ":"); i >= 0 {
+			return strings.TrimSpace(s[:i]), s[i:], nil
+		}
+		return strings.TrimSpace(s), "", nil
+	}
+}
+
+func isYAML(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,175 @@
+package colors
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNameLock(t *testing.T) {
+	for _, fileName := range []string{"names.json", "names.yaml"} {
+		t.Run(fileName, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "lock-test")
+			if err != nil {
+				t.Fatalf("Failed to create temp directory: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+			path := filepath.Join(tempDir, fileName)
+
+			lock, err := LoadNameLock(path)
+			if err != nil {
+				t.Fatalf("LoadNameLock() error = %v", err)
+			}
+
+			first := []ColorMatch{
+				{Original: "#ff0000", Variable: "--color-red"},
+				{Original: "rgb(0, 0, 255)", Variable: "--color-blue"},
+			}
+			if added := lock.Apply(first); len(added) != 2 {
+				t.Errorf("Apply() added %v, want 2 colors", added)
+			}
+			if err := lock.Save(); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			// Rename a variable by hand, as a user would.
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read names file: %v", err)
+			}
+			data = []byte(strings.Replace(string(data), "--color-red", "--color-brand", 1))
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				t.Fatalf("Failed to write names file: %v", err)
+			}
+
+			lock, err = LoadNameLock(path)
+			if err != nil {
+				t.Fatalf("LoadNameLock() error = %v", err)
+			}
+
+			second := []ColorMatch{
+				{Original: "#00ff00", Variable: "--color-brand"},
+				{Original: "rgb(0, 0, 255)", Variable: "--color-navy"},
+				{Original: "#ff0000", Variable: "--color-red"},
+			}
+			added := lock.Apply(second)
+			if len(added) != 1 || added[0] != "#00ff00" {
+				t.Errorf("Apply() added %v, want [#00ff00]", added)
+			}
+
+			want := []string{"--color-brand-2", "--color-blue", "--color-brand"}
+			for i := range want {
+				if second[i].Variable != want[i] {
+					t.Errorf("%s Variable = %q, want %q", second[i].Original, second[i].Variable, want[i])
+				}
+			}
+
+			if err := lock.Save(); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			lock, err = LoadNameLock(path)
+			if err != nil {
+				t.Fatalf("LoadNameLock() error = %v", err)
+			}
+			if got := strings.Join(lock.colors, " | "); got != "#ff0000 | rgb(0, 0, 255) | #00ff00" {
+				t.Errorf("lock order = %q, want new colors appended", got)
+			}
+		})
+	}
+}
+
+func TestLoadNameLock_Errors(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lock-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name        string
+		fileName    string
+		content     string
+		errContains string
+	}{
+		{
+			name:        "invalid json",
+			fileName:    "names.json",
+			content:     `["#fff"]`,
+			errContains: "expected a JSON object",
+		},
+		{
+			name:        "invalid variable name",
+			fileName:    "names.json",
+			content:     `{"#fff": "white"}`,
+			errContains: `invalid variable name "white"`,
+		},
+		{
+			name:        "duplicate name",
+			fileName:    "names.json",
+			content:     `{"#f00": "--brand", "#00f": "--brand"}`,
+			errContains: "colors #f00 and #00f both map to --brand",
+		},
+		{
+			name:        "unterminated single quote",
+			fileName:    "names.yaml",
+			content:     "'#fff'': --color-white\n",
+			errContains: "unterminated string",
+		},
+		{
+			name:        "invalid yaml",
+			fileName:    "names.yml",
+			content:     "\"#fff\" --color-white\n",
+			errContains: "line 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tempDir, tt.fileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write names file: %v", err)
+			}
+
+			_, err := LoadNameLock(path)
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("LoadNameLock() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+	// Spellings of one color may share a name
+	path := filepath.Join(tempDir, "spellings.json")
+	if err := os.WriteFile(path, []byte(`{"#fff": "--white", "#FFFFFF": "--white"}`), 0644); err != nil {
+		t.Fatalf("Failed to write names file: %v", err)
+	}
+	if _, err := LoadNameLock(path); err != nil {
+		t.Errorf("LoadNameLock() error = %v", err)
+	}
+}
+
+func TestYAMLScalar(t *testing.T) {
+	tests := []struct {
+		input string
+		value string
+		rest  string
+	}{
+		{`#fff: --color-white`, "#fff", ": --color-white"},
+		{`"#fff": "--color-white"`, "#fff", `: "--color-white"`},
+		{`"a\\": b`, `a\`, ": b"},
+		{`"say \"hi\"": b`, `say "hi"`, ": b"},
+		{`'#fff': '--color-white'`, "#fff", ": '--color-white'"},
+		{`'it''s': b`, "it's", ": b"},
+		{`'''': b`, "'", ": b"},
+	}
+
+	for _, tt := range tests {
+		value, rest, err := yamlScalar(tt.input)
+		if err != nil {
+			t.Errorf("yamlScalar(%q) error = %v", tt.input, err)
+			continue
+		}
+		if value != tt.value || rest != tt.rest {
+			t.Errorf("yamlScalar(%q) = %q, %q, want %q, %q", tt.input, value, rest, tt.value, tt.rest)
+		}
+	}
+}
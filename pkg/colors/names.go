@@ -0,0 +1,77 @@
+package colors
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// NamedColor is a color with a human readable name, as found in the
+// embedded dictionary or in user supplied palettes.
+type NamedColor struct {
+	Name    string
+	R, G, B uint8
+}
+
+//go:embed colornames.txt
+var colorNamesData string
+
+// colorNames holds the CSS named colors plus a curated list of common
+// color names, one "name #rrggbb" entry per line in colornames.txt.
+var colorNames = parseColorNames(colorNamesData)
+
+func parseColorNames(data string) []NamedColor {
+	var names []NamedColor
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		r, g, b, _ := ParseToRGBA(fields[1])
+		names = append(names, NamedColor{Name: fields[0], R: r, G: g, B: b})
+	}
+	return names
+}
+
+// NearestNamed returns the entry of candidates that is perceptually closest
+// to the given color together with its CIEDE2000 distance.
+func NearestNamed(r, g, b uint8, candidates []NamedColor) (NamedColor, float64) {
+	var nearest NamedColor
+	best := math.Inf(1)
+	for _, candidate := range candidates {
+		d := DeltaE(r, g, b, candidate.R, candidate.G, candidate.B)
+		if d < best {
+			nearest = candidate
+			best = d
+		}
+	}
+	return nearest, best
+}
+
+// NearestName returns the dictionary name closest to color, e.g. "black"
+// for "#010101", with a "light"/"dark" suffix when the color is clearly
+// lighter or darker than the named color and an alpha suffix like "a50"
+// for translucent colors.
+func NearestName(color string) string {
+	r, g, b, a := ParseToRGBA(color)
+	named, _ := NearestNamed(r, g, b, colorNames)
+
+	name := named.Name
+	l, _, _ := ToLab(r, g, b)
+	namedL, _, _ := ToLab(named.R, named.G, named.B)
+	if l-namedL > 10 {
+		name += "-light"
+	} else if namedL-l > 10 {
+		name += "-dark"
+	}
+
+	return name + alphaSuffix(a)
+}
+
+func alphaSuffix(a float64) string {
+	if a >= 1 {
+		return ""
+	}
+	return fmt.Sprintf("-a%d", int(math.Round(a*100)))
+}
@@ -0,0 +1,41 @@
+package colors
+
+import "testing"
+
+func TestNearestName(t *testing.T) {
+	tests := []struct {
+		name  string
+		color string
+		want  string
+	}{
+		{name: "exact css name", color: "#ff0000", want: "red"},
+		{name: "near black", color: "#010101", want: "black"},
+		{name: "rgb input", color: "rgb(70, 130, 180)", want: "steelblue"},
+		{name: "translucent", color: "rgba(0, 0, 0, 0.5)", want: "black-a50"},
+		{name: "curated name", color: "#36454f", want: "charcoal"},
+		{name: "lighter than nearest", color: "#c8c8c8", want: "silver"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NearestName(tt.color); got != tt.want {
+				t.Errorf("NearestName(%q) = %q, want %q", tt.color, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNearestNamed(t *testing.T) {
+	candidates := []NamedColor{
+		{Name: "brand", R: 0xe1, G: 0x00, B: 0x2d},
+		{Name: "ink", R: 0x11, G: 0x11, B: 0x11},
+	}
+
+	got, distance := NearestNamed(0xe0, 0x01, 0x2d, candidates)
+	if got.Name != "brand" {
+		t.Errorf("NearestNamed() = %q, want %q", got.Name, "brand")
+	}
+	if distance <= 0 || distance > 1 {
+		t.Errorf("NearestNamed() distance = %v, want a small positive distance", distance)
+	}
+}
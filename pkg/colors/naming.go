@@ -0,0 +1,130 @@
+package colors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var textRanks = []string{"primary", "secondary", "tertiary"}
+
+// ApplyNaming assigns a variable name to every match using the given
+// strategy:
+//   - "value": derived from the color value (default)
+//   - "name":  nearest color name from the embedded dictionary
+//   - "role":  how the color is used, e.g. --color-text-primary, --color-bg-1
+//   - "scale": hue family and lightness step, e.g. --color-blue-500
+//
+// It returns notes about names that may need a closer look.
+func ApplyNaming(matches []ColorMatch, strategy string) ([]string, error) {
+	switch strategy {
+	case "", "value":
+		for i := range matches {
+			matches[i].Variable = GenerateVariableName(matches[i].Original)
+		}
+	case "name":
+		assignNearestNames(matches)
+	case "role":
+		assignRoleNames(matches)
+	case "scale":
+		return assignScaleNames(matches), nil
+	default:
+		return nil, fmt.Errorf("unsupported naming strategy: %s", strategy)
+	}
+
+	return nil, nil
+}
+
+func assignNearestNames(matches []ColorMatch) {
+	used := make(map[string]string)
+	for i := range matches {
+		key := rgbaKey(matches[i].Original)
+		base := "--color-" + NearestName(matches[i].Original)
+
+		name := base
+		for n := 2; used[name] != "" && used[name] != key; n++ {
+			name = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		used[name] = key
+		matches[i].Variable = name
+	}
+}
+
+func rgbaKey(color string) string {
+	r, g, b, a := ParseToRGBA(color)
+	return fmt.Sprintf("%d,%d,%d,%.2f", r, g, b, a)
+}
+
+// PropertyRole classifies a CSS property by what it colors: "text", "bg",
+// "border", "shadow" or "fill". Anything else, including SCSS variables,
+// is "other".
+func PropertyRole(property string) string {
+	switch {
+	case property == "color" || property == "caret-color" ||
+		property == "-webkit-text-fill-color" || strings.HasPrefix(property, "text-decoration"):
+		return "text"
+	case strings.HasPrefix(property, "background"):
+		return "bg"
+	case strings.HasPrefix(property, "border") || strings.HasPrefix(property, "outline") ||
+		strings.HasPrefix(property, "column-rule"):
+		return "border"
+	case strings.HasSuffix(property, "shadow"):
+		return "shadow"
+	case property == "fill" || property == "stroke":
+		return "fill"
+	default:
+		return "other"
+	}
+}
+
+// MatchRole returns the role a color is used in most often. Ties go to the
+// role that occurs first in the file.
+func MatchRole(match ColorMatch) string {
+	counts := make(map[string]int)
+	var order []string
+	for _, occurrence := range match.Occurrences {
+		role := PropertyRole(occurrence.Property)
+		if role == "other" {
+			continue
+		}
+		if counts[role] == 0 {
+			order = append(order, role)
+		}
+		counts[role]++
+	}
+
+	best := "other"
+	for _, role := range order {
+		if counts[role] > counts[best] {
+			best = role
+		}
+	}
+	return best
+}
+
+// assignRoleNames names each color after its role, ranked within the role
+// by usage count so the most used text color becomes --color-text-primary
+// and the most used background --color-bg-1. Ties keep file order, which
+// keeps names stable between runs on an unchanged file.
+func assignRoleNames(matches []ColorMatch) {
+	byRole := make(map[string][]int)
+	for i := range matches {
+		role := MatchRole(matches[i])
+		byRole[role] = append(byRole[role], i)
+	}
+
+	for role, indexes := range byRole {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return len(matches[indexes[a]].Occurrences) > len(matches[indexes[b]].Occurrences)
+		})
+
+		for rank, i := range indexes {
+			suffix := fmt.Sprintf("%d", rank+1)
+			if role == "text" && rank < len(textRanks) {
+				suffix = textRanks[rank]
+			}
+			matches[i].Variable = fmt.Sprintf("--color-%s-%s", role, suffix)
+		}
+	}
+}
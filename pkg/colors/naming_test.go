@@ -0,0 +1,119 @@
+package colors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyNaming(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		colors   []string
+		want     []string
+	}{
+		{
+			name:     "value",
+			strategy: "value",
+			colors:   []string{"#ff0000", "rgb(0, 255, 0)"},
+			want:     []string{"--color-ff0000", "--color-rgb-0-255-0"},
+		},
+		{
+			name:     "default is value",
+			strategy: "",
+			colors:   []string{"#ff0000"},
+			want:     []string{"--color-ff0000"},
+		},
+		{
+			name:     "nearest name",
+			strategy: "name",
+			colors:   []string{"#ff0000", "rgba(0, 0, 0, 0.5)", "#000"},
+			want:     []string{"--color-red", "--color-black-a50", "--color-black"},
+		},
+		{
+			name:     "nearest name collision",
+			strategy: "name",
+			colors:   []string{"#ff0000", "#fe0000", "#FF0000"},
+			want:     []string{"--color-red", "--color-red-2", "--color-red"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := make([]ColorMatch, len(tt.colors))
+			for i, c := range tt.colors {
+				matches[i] = ColorMatch{Original: c, Value: c}
+			}
+
+			if _, err := ApplyNaming(matches, tt.strategy); err != nil {
+				t.Fatalf("ApplyNaming() error = %v", err)
+			}
+
+			for i, want := range tt.want {
+				if matches[i].Variable != want {
+					t.Errorf("match %d (%s) Variable = %q, want %q", i, tt.colors[i], matches[i].Variable, want)
+				}
+			}
+		})
+	}
+
+	_, err := ApplyNaming(nil, "bogus")
+	if err == nil || !strings.Contains(err.Error(), "unsupported naming strategy") {
+		t.Errorf("ApplyNaming() error = %v, want unsupported naming strategy", err)
+	}
+}
+
+func TestApplyNaming_Role(t *testing.T) {
+	matches := []ColorMatch{
+		{Original: "#333", Occurrences: []Occurrence{{Property: "color"}}},
+		{Original: "#fff", Occurrences: []Occurrence{{Property: "background"}, {Property: "color"}, {Property: "background-color"}}},
+		{Original: "#000", Occurrences: []Occurrence{{Property: "color"}, {Property: "color"}}},
+		{Original: "#ccc", Occurrences: []Occurrence{{Property: "border-bottom"}}},
+		{Original: "#eee", Occurrences: []Occurrence{{Property: "background"}}},
+		{Original: "#f00", Occurrences: []Occurrence{{Property: "$brand"}}},
+		{Original: "#00f", Occurrences: []Occurrence{{Property: "color"}}},
+		{Original: "#0f0", Occurrences: []Occurrence{{Property: "color"}}},
+	}
+
+	if _, err := ApplyNaming(matches, "role"); err != nil {
+		t.Fatalf("ApplyNaming() error = %v", err)
+	}
+
+	want := []string{
+		"--color-text-secondary",
+		"--color-bg-1",
+		"--color-text-primary",
+		"--color-border-1",
+		"--color-bg-2",
+		"--color-other-1",
+		"--color-text-tertiary",
+		"--color-text-4",
+	}
+	for i := range want {
+		if matches[i].Variable != want[i] {
+			t.Errorf("%s Variable = %q, want %q", matches[i].Original, matches[i].Variable, want[i])
+		}
+	}
+}
+
+func TestPropertyRole(t *testing.T) {
+	tests := map[string]string{
+		"color":                 "text",
+		"text-decoration-color": "text",
+		"background":            "bg",
+		"background-color":      "bg",
+		"border-left-color":     "border",
+		"outline":               "border",
+		"box-shadow":            "shadow",
+		"text-shadow":           "shadow",
+		"fill":                  "fill",
+		"$primary":              "other",
+		"":                      "other",
+	}
+
+	for property, want := range tests {
+		if got := PropertyRole(property); got != want {
+			t.Errorf("PropertyRole(%q) = %q, want %q", property, got, want)
+		}
+	}
+}
@@ -11,16 +11,27 @@ import (
 )
 
 type ColorMatch struct {
-	Original string
-	Variable string
-	Value    string
+	Original    string
+	Variable    string
+	Value       string
+	Line        int
+	Occurrences []Occurrence
+}
+
+// Occurrence records one place a color appears in the scanned file.
+type Occurrence struct {
 	Line     int
+	Property string
 }
 
 var (
 	hexColorRegex  = regexp.MustCompile(`(?i)#([0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})(?:[)\s;,}]|$)`)
 	rgbColorRegex  = regexp.MustCompile(`rgb\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*\)`)
 	rgbaColorRegex = regexp.MustCompile(`rgba\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(0|1|0?\.\d+)\s*\)`)
+	propertyRegex  = regexp.MustCompile(`^[$@]?-?[a-zA-Z_][\w-]*$`)
+	// unquotedURLRegex matches the start of a url() whose argument is not
+	// a quoted string.
+	unquotedURLRegex = regexp.MustCompile(`^(?i)url\(\s*[^\s"')]`)
 )
 
 func ScanFile(filepath string) ([]ColorMatch, error) {
@@ -31,73 +42,161 @@ func ScanFile(filepath string) ([]ColorMatch, error) {
 	defer file.Close()
 
 	var matches []ColorMatch
-	colorMap := make(map[string]bool)
+	colorMap := make(map[string]int)
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
 
+	addMatch := func(color string, line string, pos int) {
+		occurrence := Occurrence{
+			Line:     lineNum,
+			Property: propertyAt(line, pos),
+		}
+		if i, ok := colorMap[color]; ok {
+			matches[i].Occurrences = append(matches[i].Occurrences, occurrence)
+			return
+		}
+		colorMap[color] = len(matches)
+		matches = append(matches, ColorMatch{
+			Original:    color,
+			Variable:    GenerateVariableName(color),
+			Value:       color,
+			Line:        lineNum,
+			Occurrences: []Occurrence{occurrence},
+		})
+	}
+
 	for scanner.Scan() {
 		lineNum++
 		line := scanner.Text()
 
-		hexMatches := hexColorRegex.FindAllStringSubmatchIndex(line, -1)
-		for _, match := range hexMatches {
-			if len(match) >= 4 {
-				start := match[0] // Start of entire match
-				end := match[3]   // End of the hex color part
-				if start >= 0 && end <= len(line) {
-					colorPart := line[start:end]
-					colorPart = strings.TrimSpace(colorPart)
-					if !colorMap[colorPart] {
-						colorMap[colorPart] = true
-						varName := GenerateVariableName(colorPart)
-						matches = append(matches, ColorMatch{
-							Original: colorPart,
-							Variable: varName,
-							Value:    colorPart,
-							Line:     lineNum,
-						})
-					}
-				}
-			}
+		for _, span := range FindColors(line) {
+			addMatch(line[span[0]:span[1]], line, span[0])
 		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error scanning file: %w", err)
+	}
+
+	return matches, nil
+}
+
+// IsColor reports whether value is a single hex, rgb() or rgba() color.
+func IsColor(value string) bool {
+	spans := FindColors(value)
+	return len(spans) == 1 && spans[0][0] == 0 && spans[0][1] == len(value)
+}
+
+// FindColors returns the [start, end) byte offsets of all hex, rgb() and
+// rgba() colors in text, grouped by kind in that order.
+func FindColors(text string) [][2]int {
+	var spans [][2]int
 
-		rgbMatches := rgbColorRegex.FindAllString(line, -1)
-		for _, match := range rgbMatches {
-			if !colorMap[match] {
-				colorMap[match] = true
-				varName := GenerateVariableName(match)
-				matches = append(matches, ColorMatch{
-					Original: match,
-					Variable: varName,
-					Value:    match,
-					Line:     lineNum,
-				})
+	hexMatches := hexColorRegex.FindAllStringSubmatchIndex(text, -1)
+	for _, match := range hexMatches {
+		if len(match) >= 4 {
+			start := match[0] // Start of entire match
+			end := match[3]   // End of the hex color part
+			if start >= 0 && end <= len(text) {
+				spans = append(spans, [2]int{start, end})
 			}
 		}
+	}
+
+	for _, match := range rgbColorRegex.FindAllStringIndex(text, -1) {
+		spans = append(spans, [2]int{match[0], match[1]})
+	}
+
+	for _, match := range rgbaColorRegex.FindAllStringIndex(text, -1) {
+		spans = append(spans, [2]int{match[0], match[1]})
+	}
+
+	return spans
+}
 
-		rgbaMatches := rgbaColorRegex.FindAllString(line, -1)
-		for _, match := range rgbaMatches {
-			if !colorMap[match] {
-				colorMap[match] = true
-				varName := GenerateVariableName(match)
-				matches = append(matches, ColorMatch{
-					Original: match,
-					Variable: varName,
-					Value:    match,
-					Line:     lineNum,
-				})
+// SkippedSpans returns the [start, end) byte offsets of the comments,
+// quoted strings and unquoted url() arguments in text, in order. Their
+// content is not CSS syntax, so a ";" or "}" inside them ends nothing.
+// With scss, "//" line comments are included up to the end of their line.
+// An unterminated comment runs to the end of the text and an unterminated
+// string to the end of its line.
+func SkippedSpans(text string, scss bool) [][2]int {
+	var spans [][2]int
+	for i := 0; i < len(text); i++ {
+		switch {
+		case strings.HasPrefix(text[i:], "/*"):
+			end := len(text)
+			if j := strings.Index(text[i+2:], "*/"); j >= 0 {
+				end = i + 2 + j + 2
+			}
+			spans = append(spans, [2]int{i, end})
+			i = end - 1
+		case scss && strings.HasPrefix(text[i:], "//"):
+			end := len(text)
+			if j := strings.IndexByte(text[i:], '\n'); j >= 0 {
+				end = i + j
+			}
+			spans = append(spans, [2]int{i, end})
+			i = end - 1
+		case text[i] == '"' || text[i] == '\'':
+			j := i + 1
+			for j < len(text) && text[j] != text[i] && text[j] != '\n' {
+				if text[j] == '\\' {
+					j++
+				}
+				j++
 			}
+			end := min(j+1, len(text))
+			spans = append(spans, [2]int{i, end})
+			i = end - 1
+		case unquotedURLRegex.MatchString(text[i:]) && (i == 0 || !isNameByte(text[i-1])):
+			// Unquoted URLs may contain "//" and, in data URIs, ";"
+			start := i + len("url(")
+			end := len(text)
+			if j := strings.IndexByte(text[start:], ')'); j >= 0 {
+				end = start + j
+			}
+			spans = append(spans, [2]int{start, end})
+			i = end - 1
 		}
 	}
+	return spans
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error scanning file: %w", err)
+func isNameByte(c byte) bool {
+	return c == '-' || c == '_' || c >= 0x80 || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// propertyAt returns the name of the declaration the color at pos belongs
+// to, e.g. "border-color" for "  border-color: #fff;". SCSS variables are
+// returned with their "$" prefix. It returns "" when the property cannot be
+// determined from the line alone.
+func propertyAt(line string, pos int) string {
+	prefix := line[:pos]
+	if i := strings.LastIndexAny(prefix, ";{}"); i >= 0 {
+		prefix = prefix[i+1:]
 	}
 
-	return matches, nil
+	colon := strings.Index(prefix, ":")
+	if colon < 0 {
+		return ""
+	}
+
+	property := strings.TrimSpace(prefix[:colon])
+	if !propertyRegex.MatchString(property) {
+		return ""
+	}
+	return strings.ToLower(property)
 }
 
 func GenerateVariableName(color string) string {
+	return "--color-" + ValueSlug(color)
+}
+
+// ValueSlug turns a color value into the identifier fragment used by the
+// default naming, e.g. "ff0000" for "#FF0000" or "rgb-255-0-0" for
+// "rgb(255, 0, 0)".
+func ValueSlug(color string) string {
 	name := strings.ToLower(color)
 	name = strings.ReplaceAll(name, "#", "")
 	name = strings.ReplaceAll(name, "(", "-")
@@ -108,15 +207,7 @@ func GenerateVariableName(color string) string {
 	name = strings.ReplaceAll(name, " ", "-")
 	name = regexp.MustCompile(`-+`).ReplaceAllString(name, "-")
 
-	if !strings.HasPrefix(name, "--color-") {
-		name = "--color-" + name
-	}
-
-	if strings.Contains(name, "-rgb") {
-		name = strings.TrimSuffix(name, "-") + "-"
-	}
-
-	return name
+	return strings.Trim(name, "-")
 }
 
 func ConvertColor(color, format string) (string, error) {
@@ -133,6 +224,13 @@ func ConvertColor(color, format string) (string, error) {
 		return fmt.Sprintf("rgb(%d, %d, %d)", r, g, b), nil
 	case "rgba":
 		return fmt.Sprintf("rgba(%d, %d, %d, %.2f)", r, g, b, a), nil
+	case "hsl":
+		h, s, l := ToHSL(r, g, b)
+		h = math.Mod(math.Round(h), 360)
+		if a < 1 {
+			return fmt.Sprintf("hsla(%.0f, %.0f%%, %.0f%%, %.2f)", h, s*100, l*100, a), nil
+		}
+		return fmt.Sprintf("hsl(%.0f, %.0f%%, %.0f%%)", h, s*100, l*100), nil
 	default:
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
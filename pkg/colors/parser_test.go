@@ -51,6 +51,18 @@ func TestConvertColor(t *testing.T) {
 			format: "rgb",
 			want:   "rgb(255, 0, 0)",
 		},
+		{
+			name:   "hex to hsl",
+			input:  "#3366cc",
+			format: "hsl",
+			want:   "hsl(220, 60%, 50%)",
+		},
+		{
+			name:   "rgba to hsla",
+			input:  "rgba(255, 0, 0, 0.5)",
+			format: "hsl",
+			want:   "hsla(0, 100%, 50%, 0.50)",
+		},
 		{
 			name:        "invalid format",
 			input:       "#ff0000",
@@ -94,12 +106,12 @@ func TestGenerateVariableName(t *testing.T) {
 		{
 			name:  "rgb color",
 			color: "rgb(255, 0, 0)",
-			want:  "--color-rgb-255-0-0-",
+			want:  "--color-rgb-255-0-0",
 		},
 		{
 			name:  "rgba color",
 			color: "rgba(255, 0, 0, 0.5)",
-			want:  "--color-rgba-255-0-0-0-5-",
+			want:  "--color-rgba-255-0-0-0-5",
 		},
 	}
 
@@ -112,6 +124,55 @@ func TestGenerateVariableName(t *testing.T) {
 	}
 }
 
+func TestIsColor(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"#ff0000", true},
+		{"rgba(0, 0, 0, 0.5)", true},
+		{"red", false},
+		{"#ff0000 #00ff00", false},
+		{"1px solid #ff0000", false},
+		{"var(--color-red)", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsColor(tt.value); got != tt.want {
+			t.Errorf("IsColor(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSkippedSpans(t *testing.T) {
+	tests := []struct {
+		text string
+		scss bool
+		want []string
+	}{
+		{`.a { color: #fff; }`, false, nil},
+		{`/* a; b */ color: #fff;`, false, []string{`/* a; b */`}},
+		{`url("data:a;b") 'it\'s'`, false, []string{`"data:a;b"`, `'it\'s'`}},
+		{`"/* not a comment */" /* "not a string" */`, false, []string{`"/* not a comment */"`, `/* "not a string" */`}},
+		{"/* open", false, []string{"/* open"}},
+		{"'open\nnext", false, []string{"'open\n"}},
+		{"// brand\ncolor: #fff;", false, nil},
+		{"// brand\ncolor: #fff;", true, []string{"// brand"}},
+		{"a: url(http://x/a;b.png) #fff; // end", true, []string{"http://x/a;b.png", "// end"}},
+		{"a: myurl(b) url( 'c' )", false, []string{"'c'"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, span := range SkippedSpans(tt.text, tt.scss) {
+			got = append(got, tt.text[span[0]:span[1]])
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("SkippedSpans(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
 func TestParseToRGBA(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -235,3 +296,63 @@ func TestScanFile(t *testing.T) {
 		}
 	}
 }
+
+func TestScanFile_Occurrences(t *testing.T) {
+	content := `$brand: #ff0000;
+.test { color: #ff0000; background-color: #fff; }
+.other {
+	border: 1px solid #ff0000;
+	box-shadow: 0 0 2px rgba(0, 0, 0, 0.5),
+		0 0 4px rgba(0, 0, 0, 0.5);
+}
+`
+	tmpfile, err := os.CreateTemp("", "test*.scss")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpfile.Name())
+
+	if _, err := tmpfile.Write([]byte(content)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		t.Fatalf("Failed to close temp file: %v", err)
+	}
+
+	matches, err := ScanFile(tmpfile.Name())
+	if err != nil {
+		t.Fatalf("ScanFile() error = %v", err)
+	}
+
+	expected := map[string][]Occurrence{
+		"#ff0000": {
+			{Line: 1, Property: "$brand"},
+			{Line: 2, Property: "color"},
+			{Line: 4, Property: "border"},
+		},
+		"#fff": {
+			{Line: 2, Property: "background-color"},
+		},
+		"rgba(0, 0, 0, 0.5)": {
+			{Line: 5, Property: "box-shadow"},
+			{Line: 6, Property: ""},
+		},
+	}
+
+	if len(matches) != len(expected) {
+		t.Fatalf("ScanFile() returned %d matches, want %d", len(matches), len(expected))
+	}
+
+	for _, match := range matches {
+		want := expected[match.Original]
+		if len(match.Occurrences) != len(want) {
+			t.Errorf("%s has %d occurrences, want %d", match.Original, len(match.Occurrences), len(want))
+			continue
+		}
+		for i := range want {
+			if match.Occurrences[i] != want[i] {
+				t.Errorf("%s occurrence %d = %+v, want %+v", match.Original, i, match.Occurrences[i], want[i])
+			}
+		}
+	}
+}
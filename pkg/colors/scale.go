@@ -0,0 +1,167 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// scaleSteps are the lightness steps of a Tailwind-like scale with their
+// OKLCH lightness.
+var scaleSteps = []struct {
+	Step      int
+	Lightness float64
+}{
+	{50, 0.97}, {100, 0.94}, {200, 0.88}, {300, 0.81}, {400, 0.71},
+	{500, 0.62}, {600, 0.54}, {700, 0.46}, {800, 0.39}, {900, 0.31}, {950, 0.24},
+}
+
+// hueFamilies are the OKLCH hue centers colors are grouped by. Colors with
+// a chroma below grayChroma belong to "gray".
+var hueFamilies = []struct {
+	Name string
+	Hue  float64
+}{
+	{"red", 25}, {"orange", 50}, {"yellow", 90}, {"lime", 125}, {"green", 150}, {"teal", 180},
+	{"cyan", 215}, {"blue", 260}, {"indigo", 280}, {"purple", 305}, {"pink", 350},
+}
+
+const (
+	grayChroma = 0.03
+
+	// stepTolerance is how far a color's lightness may be from its step
+	// before it is reported as falling between steps.
+	stepTolerance = 0.025
+)
+
+// HueFamily returns the hue family of a color, e.g. "blue" or "gray".
+func HueFamily(r, g, b uint8) string {
+	_, c, h := ToOKLCH(r, g, b)
+	if c < grayChroma {
+		return "gray"
+	}
+
+	family := hueFamilies[0].Name
+	best := math.Inf(1)
+	for _, f := range hueFamilies {
+		d := math.Abs(h - f.Hue)
+		if d > 180 {
+			d = 360 - d
+		}
+		if d < best {
+			family = f.Name
+			best = d
+		}
+	}
+	return family
+}
+
+// HueFamilyNames returns "gray" followed by the hue families in hue order.
+func HueFamilyNames() []string {
+	names := []string{"gray"}
+	for _, f := range hueFamilies {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// assignScaleNames names colors like --color-blue-500. Opaque colors of a
+// family are ordered from light to dark and get increasing steps, so a
+// lighter color never gets a higher number than a darker one. Translucent
+// colors take the step of their lightness plus an alpha suffix. It returns
+// a note for every color that does not sit on its step.
+func assignScaleNames(matches []ColorMatch) []string {
+	type entry struct {
+		index     int
+		lightness float64
+	}
+
+	families := make(map[string][]entry)
+	var familyOrder []string
+	var notes []string
+	used := make(map[string]string)
+
+	setName := func(i int, base string) {
+		key := rgbaKey(matches[i].Original)
+		name := base
+		for n := 2; used[name] != "" && used[name] != key; n++ {
+			name = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[name] = key
+		matches[i].Variable = name
+	}
+
+	var translucent []int
+	for i := range matches {
+		r, g, b, a := ParseToRGBA(matches[i].Original)
+		if a < 1 {
+			translucent = append(translucent, i)
+			continue
+		}
+		family := HueFamily(r, g, b)
+		l, _, _ := ToOKLCH(r, g, b)
+		if _, ok := families[family]; !ok {
+			familyOrder = append(familyOrder, family)
+		}
+		families[family] = append(families[family], entry{i, l})
+	}
+
+	for _, family := range familyOrder {
+		entries := families[family]
+		sort.SliceStable(entries, func(a, b int) bool {
+			return entries[a].lightness > entries[b].lightness
+		})
+
+		prev := -1
+		for _, e := range entries {
+			step := nearestStep(e.lightness)
+			if step <= prev {
+				step = prev + 1
+			}
+
+			var base string
+			if step < len(scaleSteps) {
+				base = fmt.Sprintf("--color-%s-%d", family, scaleSteps[step].Step)
+			} else {
+				base = fmt.Sprintf("--color-%s-%d", family, scaleSteps[len(scaleSteps)-1].Step)
+			}
+			setName(e.index, base)
+			prev = step
+
+			if step >= len(scaleSteps) || math.Abs(e.lightness-scaleSteps[step].Lightness) > stepTolerance {
+				notes = append(notes, fmt.Sprintf("%s (lightness %.2f) falls between %s, named %s",
+					matches[e.index].Original, e.lightness, stepBracket(family, e.lightness), matches[e.index].Variable))
+			}
+		}
+	}
+
+	for _, i := range translucent {
+		r, g, b, a := ParseToRGBA(matches[i].Original)
+		l, _, _ := ToOKLCH(r, g, b)
+		step := scaleSteps[nearestStep(l)].Step
+		setName(i, fmt.Sprintf("--color-%s-%d%s", HueFamily(r, g, b), step, alphaSuffix(a)))
+	}
+
+	return notes
+}
+
+func nearestStep(lightness float64) int {
+	best := 0
+	for i, step := range scaleSteps {
+		if math.Abs(step.Lightness-lightness) < math.Abs(scaleSteps[best].Lightness-lightness) {
+			best = i
+		}
+	}
+	return best
+}
+
+// stepBracket describes the steps around a lightness, e.g. "blue-500 and
+// blue-600".
+func stepBracket(family string, lightness float64) string {
+	for i := 1; i < len(scaleSteps); i++ {
+		if lightness >= scaleSteps[i].Lightness {
+			return fmt.Sprintf("%s-%d and %s-%d", family, scaleSteps[i-1].Step, family, scaleSteps[i].Step)
+		}
+	}
+	return fmt.Sprintf("%s-%d and black", family, scaleSteps[len(scaleSteps)-1].Step)
+}
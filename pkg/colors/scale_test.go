@@ -0,0 +1,65 @@
+package colors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHueFamily(t *testing.T) {
+	tests := map[string]string{
+		"#3b82f6": "blue",
+		"#ef4444": "red",
+		"#22c55e": "green",
+		"#eab308": "yellow",
+		"#f3f4f6": "gray",
+		"#ffffff": "gray",
+		"#000000": "gray",
+	}
+
+	for color, want := range tests {
+		r, g, b, _ := ParseToRGBA(color)
+		if got := HueFamily(r, g, b); got != want {
+			t.Errorf("HueFamily(%s) = %q, want %q", color, got, want)
+		}
+	}
+}
+
+func TestApplyNaming_Scale(t *testing.T) {
+	originals := []string{
+		"#3b82f6",
+		"#1e3a8a",
+		"#dbeafe",
+		"#3a80f5",
+		"#ef4444",
+		"#f3f4f6",
+		"rgba(59, 130, 246, 0.5)",
+	}
+	matches := make([]ColorMatch, len(originals))
+	for i, c := range originals {
+		matches[i] = ColorMatch{Original: c, Value: c}
+	}
+
+	notes, err := ApplyNaming(matches, "scale")
+	if err != nil {
+		t.Fatalf("ApplyNaming() error = %v", err)
+	}
+
+	want := []string{
+		"--color-blue-500",
+		"--color-blue-800",
+		"--color-blue-100",
+		"--color-blue-600",
+		"--color-red-500",
+		"--color-gray-50",
+		"--color-blue-500-a50",
+	}
+	for i := range want {
+		if matches[i].Variable != want[i] {
+			t.Errorf("%s Variable = %q, want %q", matches[i].Original, matches[i].Variable, want[i])
+		}
+	}
+
+	if len(notes) != 1 || !strings.Contains(notes[0], "#3a80f5") || !strings.Contains(notes[0], "blue-500 and blue-600") {
+		t.Errorf("notes = %q, want a single note about #3a80f5 between blue-500 and blue-600", notes)
+	}
+}
@@ -0,0 +1,197 @@
+package colors
+
+import "math"
+
+// ToLab converts an sRGB color to CIE L*a*b* using the D65 white point.
+func ToLab(r, g, b uint8) (l, a, bb float64) {
+	x, y, z := toXYZ(r, g, b)
+
+	fx := labF(x / 0.95047)
+	fy := labF(y / 1.0)
+	fz := labF(z / 1.08883)
+
+	l = 116*fy - 16
+	a = 500 * (fx - fy)
+	bb = 200 * (fy - fz)
+	return
+}
+
+// DeltaE returns the CIEDE2000 color difference between two sRGB colors.
+func DeltaE(r1, g1, b1, r2, g2, b2 uint8) float64 {
+	l1, a1, bb1 := ToLab(r1, g1, b1)
+	l2, a2, bb2 := ToLab(r2, g2, b2)
+	return deltaE2000(l1, a1, bb1, l2, a2, bb2)
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio between two sRGB colors,
+// from 1 to 21.
+func ContrastRatio(r1, g1, b1, r2, g2, b2 uint8) float64 {
+	_, y1, _ := toXYZ(r1, g1, b1)
+	_, y2, _ := toXYZ(r2, g2, b2)
+	if y1 < y2 {
+		y1, y2 = y2, y1
+	}
+	return (y1 + 0.05) / (y2 + 0.05)
+}
+
+func toXYZ(r, g, b uint8) (x, y, z float64) {
+	rl := linearize(r)
+	gl := linearize(g)
+	bl := linearize(b)
+
+	x = rl*0.4124564 + gl*0.3575761 + bl*0.1804375
+	y = rl*0.2126729 + gl*0.7151522 + bl*0.0721750
+	z = rl*0.0193339 + gl*0.1191920 + bl*0.9503041
+	return
+}
+
+func linearize(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func labF(t float64) float64 {
+	if t > 216.0/24389.0 {
+		return math.Cbrt(t)
+	}
+	return (24389.0/27.0*t + 16) / 116
+}
+
+func deltaE2000(l1, a1, b1, l2, a2, b2 float64) float64 {
+	c1 := math.Hypot(a1, b1)
+	c2 := math.Hypot(a2, b2)
+	cMean := (c1 + c2) / 2
+
+	g := 0.5 * (1 - math.Sqrt(math.Pow(cMean, 7)/(math.Pow(cMean, 7)+math.Pow(25, 7))))
+	a1p := a1 * (1 + g)
+	a2p := a2 * (1 + g)
+
+	c1p := math.Hypot(a1p, b1)
+	c2p := math.Hypot(a2p, b2)
+
+	h1p := hueAngle(b1, a1p)
+	h2p := hueAngle(b2, a2p)
+
+	dLp := l2 - l1
+	dCp := c2p - c1p
+
+	var dhp float64
+	switch {
+	case c1p*c2p == 0:
+		dhp = 0
+	case math.Abs(h2p-h1p) <= 180:
+		dhp = h2p - h1p
+	case h2p-h1p > 180:
+		dhp = h2p - h1p - 360
+	default:
+		dhp = h2p - h1p + 360
+	}
+	dHp := 2 * math.Sqrt(c1p*c2p) * math.Sin(radians(dhp/2))
+
+	lMean := (l1 + l2) / 2
+	cpMean := (c1p + c2p) / 2
+
+	var hpMean float64
+	switch {
+	case c1p*c2p == 0:
+		hpMean = h1p + h2p
+	case math.Abs(h1p-h2p) <= 180:
+		hpMean = (h1p + h2p) / 2
+	case h1p+h2p < 360:
+		hpMean = (h1p + h2p + 360) / 2
+	default:
+		hpMean = (h1p + h2p - 360) / 2
+	}
+
+	t := 1 - 0.17*math.Cos(radians(hpMean-30)) +
+		0.24*math.Cos(radians(2*hpMean)) +
+		0.32*math.Cos(radians(3*hpMean+6)) -
+		0.20*math.Cos(radians(4*hpMean-63))
+
+	dTheta := 30 * math.Exp(-math.Pow((hpMean-275)/25, 2))
+	rc := 2 * math.Sqrt(math.Pow(cpMean, 7)/(math.Pow(cpMean, 7)+math.Pow(25, 7)))
+	sl := 1 + (0.015*math.Pow(lMean-50, 2))/math.Sqrt(20+math.Pow(lMean-50, 2))
+	sc := 1 + 0.045*cpMean
+	sh := 1 + 0.015*cpMean*t
+	rt := -math.Sin(radians(2*dTheta)) * rc
+
+	return math.Sqrt(math.Pow(dLp/sl, 2) +
+		math.Pow(dCp/sc, 2) +
+		math.Pow(dHp/sh, 2) +
+		rt*(dCp/sc)*(dHp/sh))
+}
+
+func hueAngle(b, a float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// ToHSL converts an sRGB color to hue in degrees [0, 360) and saturation
+// and lightness in [0, 1].
+func ToHSL(r, g, b uint8) (h, s, l float64) {
+	rf := float64(r) / 255
+	gf := float64(g) / 255
+	bf := float64(b) / 255
+
+	max := math.Max(rf, math.Max(gf, bf))
+	min := math.Min(rf, math.Min(gf, bf))
+	l = (max + min) / 2
+
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+
+	switch max {
+	case rf:
+		h = (gf - bf) / d
+		if gf < bf {
+			h += 6
+		}
+	case gf:
+		h = (bf-rf)/d + 2
+	default:
+		h = (rf-gf)/d + 4
+	}
+	h *= 60
+	return
+}
+
+// ToOKLCH converts an sRGB color to OKLCH lightness [0, 1], chroma and hue
+// in degrees [0, 360).
+func ToOKLCH(r, g, b uint8) (l, c, h float64) {
+	rl := linearize(r)
+	gl := linearize(g)
+	bl := linearize(b)
+
+	lms1 := math.Cbrt(0.4122214708*rl + 0.5363325363*gl + 0.0514459929*bl)
+	lms2 := math.Cbrt(0.2119034982*rl + 0.6806995451*gl + 0.1073969566*bl)
+	lms3 := math.Cbrt(0.0883024619*rl + 0.2817188376*gl + 0.6299787005*bl)
+
+	l = 0.2104542553*lms1 + 0.7936177850*lms2 - 0.0040720468*lms3
+	a := 1.9779984951*lms1 - 2.4285922050*lms2 + 0.4505937099*lms3
+	bb := 0.0259040371*lms1 + 0.7827717662*lms2 - 0.8086757660*lms3
+
+	c = math.Hypot(a, bb)
+	h = hueAngle(bb, a)
+	return
+}
@@ -0,0 +1,122 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToLab(t *testing.T) {
+	tests := []struct {
+		name         string
+		r, g, b      uint8
+		wantL, wantA float64
+		wantB        float64
+	}{
+		{name: "white", r: 255, g: 255, b: 255, wantL: 100, wantA: 0, wantB: 0},
+		{name: "black", r: 0, g: 0, b: 0, wantL: 0, wantA: 0, wantB: 0},
+		{name: "red", r: 255, g: 0, b: 0, wantL: 53.24, wantA: 80.09, wantB: 67.20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, a, b := ToLab(tt.r, tt.g, tt.b)
+			if math.Abs(l-tt.wantL) > 0.05 || math.Abs(a-tt.wantA) > 0.05 || math.Abs(b-tt.wantB) > 0.05 {
+				t.Errorf("ToLab() = (%.2f, %.2f, %.2f), want (%.2f, %.2f, %.2f)", l, a, b, tt.wantL, tt.wantA, tt.wantB)
+			}
+		})
+	}
+}
+
+func TestDeltaE2000(t *testing.T) {
+	// Reference pairs from Sharma, Wu and Dalal, "The CIEDE2000
+	// Color-Difference Formula: Implementation Notes".
+	tests := []struct {
+		l1, a1, b1 float64
+		l2, a2, b2 float64
+		want       float64
+	}{
+		{50, 2.6772, -79.7751, 50, 0, -82.7485, 2.0425},
+		{50, 2.5, 0, 50, 0, -2.5, 4.3065},
+		{50, 2.5, 0, 73, 25, -18, 27.1492},
+		{60.2574, -34.0099, 36.2677, 60.4626, -34.1751, 39.4387, 1.2644},
+		{22.7233, 20.0904, -46.6940, 23.0331, 14.9730, -42.5619, 2.0373},
+	}
+
+	for _, tt := range tests {
+		got := deltaE2000(tt.l1, tt.a1, tt.b1, tt.l2, tt.a2, tt.b2)
+		if math.Abs(got-tt.want) > 0.0001 {
+			t.Errorf("deltaE2000(%v, %v, %v, %v, %v, %v) = %.4f, want %.4f",
+				tt.l1, tt.a1, tt.b1, tt.l2, tt.a2, tt.b2, got, tt.want)
+		}
+	}
+
+	if got := DeltaE(12, 34, 56, 12, 34, 56); got != 0 {
+		t.Errorf("DeltaE() of identical colors = %v, want 0", got)
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		name       string
+		r1, g1, b1 uint8
+		r2, g2, b2 uint8
+		want       float64
+	}{
+		{name: "black on white", r1: 0, g1: 0, b1: 0, r2: 255, g2: 255, b2: 255, want: 21},
+		{name: "white on black", r1: 255, g1: 255, b1: 255, r2: 0, g2: 0, b2: 0, want: 21},
+		{name: "same color", r1: 51, g1: 102, b1: 204, r2: 51, g2: 102, b2: 204, want: 1},
+		{name: "gray on white", r1: 118, g1: 118, b1: 118, r2: 255, g2: 255, b2: 255, want: 4.54},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ContrastRatio(tt.r1, tt.g1, tt.b1, tt.r2, tt.g2, tt.b2)
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("ContrastRatio() = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToHSL(t *testing.T) {
+	tests := []struct {
+		name    string
+		r, g, b uint8
+		h, s, l float64
+	}{
+		{name: "red", r: 255, g: 0, b: 0, h: 0, s: 1, l: 0.5},
+		{name: "green", r: 0, g: 128, b: 0, h: 120, s: 1, l: 0.25},
+		{name: "steelblue", r: 70, g: 130, b: 180, h: 207.27, s: 0.44, l: 0.49},
+		{name: "gray", r: 128, g: 128, b: 128, h: 0, s: 0, l: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, s, l := ToHSL(tt.r, tt.g, tt.b)
+			if math.Abs(h-tt.h) > 0.01 || math.Abs(s-tt.s) > 0.01 || math.Abs(l-tt.l) > 0.01 {
+				t.Errorf("ToHSL() = (%.2f, %.2f, %.2f), want (%.2f, %.2f, %.2f)", h, s, l, tt.h, tt.s, tt.l)
+			}
+		})
+	}
+}
+
+func TestToOKLCH(t *testing.T) {
+	tests := []struct {
+		name    string
+		r, g, b uint8
+		l, c, h float64
+	}{
+		{name: "white", r: 255, g: 255, b: 255, l: 1, c: 0, h: -1},
+		{name: "red", r: 255, g: 0, b: 0, l: 0.628, c: 0.258, h: 29.2},
+		{name: "blue-500", r: 0x3b, g: 0x82, b: 0xf6, l: 0.623, c: 0.188, h: 259.8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, c, h := ToOKLCH(tt.r, tt.g, tt.b)
+			if math.Abs(l-tt.l) > 0.001 || math.Abs(c-tt.c) > 0.001 || (tt.h >= 0 && math.Abs(h-tt.h) > 0.1) {
+				t.Errorf("ToOKLCH() = (%.3f, %.3f, %.1f), want (%.3f, %.3f, %.1f)", l, c, h, tt.l, tt.c, tt.h)
+			}
+		})
+	}
+}
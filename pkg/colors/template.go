@@ -0,0 +1,61 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"text/template"
+)
+
+// NameData is the data available to naming templates.
+type NameData struct {
+	Hex       string // canonical hex without "#", e.g. "ff0000" or "ff000080"
+	Hue       int    // HSL hue in degrees, 0-359
+	Lightness int    // HSL lightness in percent, 0-100
+	Alpha     int    // alpha in percent, 0-100
+	Name      string // nearest color name, e.g. "black-a50"
+	Role      string // usage role, e.g. "text" or "bg"
+	Index     int    // 1-based position in the file
+	Slug      string // value based fragment, e.g. "rgb-255-0-0"
+}
+
+// ApplyNameTemplate names every match by executing a text/template such as
+// "--brand-{{.Name}}" and rejects results that are not valid custom
+// property names.
+func ApplyNameTemplate(matches []ColorMatch, text string) error {
+	tmpl, err := template.New("name").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid name template: %w", err)
+	}
+
+	for i := range matches {
+		var name strings.Builder
+		if err := tmpl.Execute(&name, NewNameData(matches[i], i+1)); err != nil {
+			return fmt.Errorf("failed to execute name template for %s: %w", matches[i].Original, err)
+		}
+
+		if !IsCustomProperty(name.String()) {
+			return fmt.Errorf("name template produced invalid custom property name %q for %s", name.String(), matches[i].Original)
+		}
+		matches[i].Variable = name.String()
+	}
+
+	return nil
+}
+
+func NewNameData(match ColorMatch, index int) NameData {
+	r, g, b, a := ParseToRGBA(match.Original)
+	h, _, l := ToHSL(r, g, b)
+	hex, _ := ConvertColor(match.Original, "hex")
+
+	return NameData{
+		Hex:       strings.TrimPrefix(hex, "#"),
+		Hue:       int(math.Round(h)) % 360,
+		Lightness: int(math.Round(l * 100)),
+		Alpha:     int(math.Round(a * 100)),
+		Name:      NearestName(match.Original),
+		Role:      MatchRole(match),
+		Index:     index,
+		Slug:      ValueSlug(match.Original),
+	}
+}
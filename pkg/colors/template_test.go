@@ -0,0 +1,86 @@
+package colors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyNameTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    string
+		want        []string
+		errContains string
+	}{
+		{
+			name:     "slug with custom prefix",
+			template: "--brand-{{.Slug}}",
+			want:     []string{"--brand-ff0000", "--brand-rgba-0-0-0-0-5"},
+		},
+		{
+			name:     "nearest name",
+			template: "--ds-color-{{.Name}}",
+			want:     []string{"--ds-color-red", "--ds-color-black-a50"},
+		},
+		{
+			name:     "hsl fields and index",
+			template: "--c-{{.Index}}-h{{.Hue}}-l{{.Lightness}}-a{{.Alpha}}",
+			want:     []string{"--c-1-h0-l50-a100", "--c-2-h0-l0-a50"},
+		},
+		{
+			name:     "hex and role",
+			template: "--{{.Role}}-{{.Hex}}",
+			want:     []string{"--text-ff0000", "--shadow-00000080"},
+		},
+		{
+			name:     "double hyphens",
+			template: "--ds-{{.Role}}--{{.Index}}",
+			want:     []string{"--ds-text--1", "--ds-shadow--2"},
+		},
+		{
+			name:        "invalid identifier",
+			template:    "--c {{.Index}}",
+			errContains: "invalid custom property name",
+		},
+		{
+			name:        "missing prefix",
+			template:    "color-{{.Index}}",
+			errContains: "invalid custom property name",
+		},
+		{
+			name:        "parse error",
+			template:    "--c-{{.Index",
+			errContains: "invalid name template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := []ColorMatch{
+				{Original: "#ff0000", Occurrences: []Occurrence{{Property: "color"}}},
+				{Original: "rgba(0, 0, 0, 0.5)", Occurrences: []Occurrence{{Property: "box-shadow"}}},
+			}
+
+			err := ApplyNameTemplate(matches, tt.template)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("ApplyNameTemplate() error = %v, want error containing %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyNameTemplate() error = %v", err)
+			}
+			// Valid names are not sanitized afterwards
+			if report := EnsureUniqueNames(matches); len(report) != 0 {
+				t.Errorf("EnsureUniqueNames() renamed %q", report)
+			}
+
+			for i, want := range tt.want {
+				if matches[i].Variable != want {
+					t.Errorf("match %d Variable = %q, want %q", i, matches[i].Variable, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+const contextLines = 3
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	a, b int // line indexes into the old and new text
+}
+
+// Unified returns a unified diff between a and b with three lines of
+// context, labelled fromName and toName. It returns "" if a and b are equal.
+func Unified(fromName, toName string, a, b []byte) string {
+	aLines := splitLines(string(a))
+	bLines := splitLines(string(b))
+
+	ops := myers(aLines, bLines)
+
+	var out strings.Builder
+	for _, hunk := range hunks(ops) {
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+
+		first := hunk[0]
+		aCount, bCount := 0, 0
+		for _, o := range hunk {
+			if o.kind != opInsert {
+				aCount++
+			}
+			if o.kind != opDelete {
+				bCount++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(first.a, aCount), hunkRange(first.b, bCount))
+
+		for _, o := range hunk {
+			var line string
+			if o.kind == opInsert {
+				line = bLines[o.b]
+			} else {
+				line = aLines[o.a]
+			}
+			out.WriteString(string(o.kind) + line)
+			if !strings.HasSuffix(line, "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+
+	return out.String()
+}
+
+// splitLines splits s into lines that keep their "\n", so that a missing
+// newline at the end of the file shows up as a change.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// hunks groups the edit script into hunks of changes surrounded by up to
+// contextLines unchanged lines, merging hunks whose context overlaps.
+func hunks(ops []op) [][]op {
+	var result [][]op
+	var current []op
+	lastChange := -1
+
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+
+		start := i - contextLines
+		if start < 0 {
+			start = 0
+		}
+		if current != nil && i-lastChange-1 <= 2*contextLines {
+			current = append(current, ops[lastChange+1:i+1]...)
+		} else {
+			if current != nil {
+				result = append(result, withTrailingContext(current, ops, lastChange))
+			}
+			current = append([]op(nil), ops[start:i+1]...)
+		}
+		lastChange = i
+	}
+
+	if current != nil {
+		result = append(result, withTrailingContext(current, ops, lastChange))
+	}
+	return result
+}
+
+func withTrailingContext(hunk, ops []op, lastChange int) []op {
+	end := lastChange + 1 + contextLines
+	if end > len(ops) {
+		end = len(ops)
+	}
+	return append(hunk, ops[lastChange+1:end]...)
+}
+
+// myers computes a shortest edit script between a and b. It uses the
+// linear space variant of Myers' O(ND) algorithm, which splits the problem
+// at the middle of an optimal path and recurses on both halves, so memory
+// stays proportional to the input however many lines change.
+func myers(a, b []string) []op {
+	var ops []op
+	compare(a, b, 0, len(a), 0, len(b), &ops)
+	return ops
+}
+
+// compare appends the edit script turning a[aLo:aHi] into b[bLo:bHi] to ops.
+func compare(a, b []string, aLo, aHi, bLo, bHi int, ops *[]op) {
+	// Lines shared at the start and end need no search
+	for aLo < aHi && bLo < bHi && a[aLo] == b[bLo] {
+		*ops = append(*ops, op{opEqual, aLo, bLo})
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && a[aHi-suffix-1] == b[bHi-suffix-1] {
+		suffix++
+	}
+	aHi -= suffix
+	bHi -= suffix
+
+	switch {
+	case aLo == aHi:
+		for y := bLo; y < bHi; y++ {
+			*ops = append(*ops, op{opInsert, aLo, y})
+		}
+	case bLo == bHi:
+		for x := aLo; x < aHi; x++ {
+			*ops = append(*ops, op{opDelete, x, bLo})
+		}
+	default:
+		x, y := split(a[aLo:aHi], b[bLo:bHi])
+		compare(a, b, aLo, aLo+x, bLo, bLo+y, ops)
+		compare(a, b, aLo+x, aHi, bLo+y, bHi, ops)
+	}
+
+	for i := 0; i < suffix; i++ {
+		*ops = append(*ops, op{opEqual, aHi + i, bHi + i})
+	}
+}
+
+// split returns a point (x, y) on a shortest edit path from a to b where
+// the forward and backward searches meet. a and b must be non-empty and
+// differ in their first and last lines.
+func split(a, b []string) (int, int) {
+	n, m := len(a), len(b)
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	forward := make([]int, 2*offset+1)
+	backward := make([]int, 2*offset+1)
+	delta := n - m
+	odd := delta%2 != 0
+
+	for d := 0; d <= maxD; d++ {
+		// Forward paths from the start, furthest x per diagonal k = x - y
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			if odd && k-delta > -d && k-delta < d && x+backward[offset+delta-k] >= n {
+				return x, y
+			}
+		}
+
+		// Backward paths from the end, counted from the end of both
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+			if !odd && delta-k >= -d && delta-k <= d && x+forward[offset+delta-k] >= n {
+				return n - x, m - y
+			}
+		}
+	}
+
+	// Unreachable for valid input: the searches always meet by maxD
+	return n, m
+}
+
+// Colorize adds ANSI colors to a unified diff for display in a terminal.
+func Colorize(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+		newline := line[len(text):]
+		switch {
+		case strings.HasPrefix(text, "---"), strings.HasPrefix(text, "+++"):
+			lines[i] = "\x1b[1m" + text + "\x1b[0m" + newline
+		case strings.HasPrefix(text, "@@"):
+			lines[i] = "\x1b[36m" + text + "\x1b[0m" + newline
+		case strings.HasPrefix(text, "-"):
+			lines[i] = "\x1b[31m" + text + "\x1b[0m" + newline
+		case strings.HasPrefix(text, "+"):
+			lines[i] = "\x1b[32m" + text + "\x1b[0m" + newline
+		}
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,168 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "equal",
+			a:    "a\nb\n",
+			b:    "a\nb\n",
+			want: "",
+		},
+		{
+			name: "single change",
+			a:    "a\nb\nc\n",
+			b:    "a\nB\nc\n",
+			want: `--- old
++++ new
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`,
+		},
+		{
+			name: "insertion at start",
+			a:    "a\nb\n",
+			b:    "x\n\na\nb\n",
+			want: `--- old
++++ new
+@@ -1,2 +1,4 @@
++x
++
+ a
+ b
+`,
+		},
+		{
+			name: "separate hunks",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			b:    "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			want: `--- old
++++ new
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -9,4 +9,4 @@
+ 9
+ 10
+ 11
+-12
++twelve
+`,
+		},
+		{
+			name: "merged hunks",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n",
+			b:    "one\n2\n3\n4\n5\n6\n7\neight\n",
+			want: `--- old
++++ new
+@@ -1,8 +1,8 @@
+-1
++one
+ 2
+ 3
+ 4
+ 5
+ 6
+ 7
+-8
++eight
+`,
+		},
+		{
+			name: "new file",
+			a:    "",
+			b:    "a\n",
+			want: `--- old
++++ new
+@@ -0,0 +1 @@
++a
+`,
+		},
+		{
+			name: "missing newline at end",
+			a:    "a\nb",
+			b:    "a\nb\n",
+			want: `--- old
++++ new
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++b
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unified("old", "new", []byte(tt.a), []byte(tt.b))
+			if got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyers_LargeFile(t *testing.T) {
+	// A rewritten stylesheet: a load rule is added and every color line
+	// changes, which used to need memory quadratic in the number of lines
+	var a, b []string
+	b = append(b, "@use 'colors' as *;\n")
+	for i := 0; i < 6000; i++ {
+		a = append(a, fmt.Sprintf(".c%d {\n", i), fmt.Sprintf("  color: #%06x;\n", i), "}\n")
+		b = append(b, fmt.Sprintf(".c%d {\n", i), fmt.Sprintf("  color: var(--color-%06x);\n", i), "}\n")
+	}
+
+	ops := myers(a, b)
+
+	var gotA, gotB []string
+	edits := 0
+	for _, o := range ops {
+		switch o.kind {
+		case opEqual:
+			gotA = append(gotA, a[o.a])
+			gotB = append(gotB, b[o.b])
+		case opDelete:
+			gotA = append(gotA, a[o.a])
+			edits++
+		case opInsert:
+			gotB = append(gotB, b[o.b])
+			edits++
+		}
+	}
+	if strings.Join(gotA, "") != strings.Join(a, "") || strings.Join(gotB, "") != strings.Join(b, "") {
+		t.Fatal("myers() edit script does not reproduce the inputs")
+	}
+	if want := 2*6000 + 1; edits != want {
+		t.Errorf("myers() made %d edits, want %d", edits, want)
+	}
+
+	diff := Unified("old", "new", []byte(strings.Join(a, "")), []byte(strings.Join(b, "")))
+	if got := strings.Count(diff, "\n@@ "); got != 1 {
+		t.Errorf("Unified() has %d hunks, want 1", got)
+	}
+}
+
+func TestColorize(t *testing.T) {
+	got := Colorize("--- old\n+++ new\n@@ -1 +1 @@\n-a\n+b\n c\n")
+	for _, want := range []string{"\x1b[1m--- old\x1b[0m", "\x1b[36m@@ -1 +1 @@\x1b[0m", "\x1b[31m-a\x1b[0m", "\x1b[32m+b\x1b[0m", "\n c\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Colorize() = %q, want it to contain %q", got, want)
+		}
+	}
+}
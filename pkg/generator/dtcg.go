@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+// RenderDTCGFile returns the colors as a Design Tokens Community Group
+// document, {"color": {"<name>": {"$type": "color", "$value": "#ff0000"}}},
+// with tokens in order of first appearance in the input. The Sort and Group
+// options of the variables file do not apply.
+func RenderDTCGFile(matches []colors.ColorMatch) ([]byte, error) {
+	unique := uniqueVariables(matches)
+	if err := checkKeys(unique, TokenName, "token"); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("{\n  \"color\": {\n")
+	for i, match := range unique {
+		value, err := colors.ConvertColor(match.Value, "hex")
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert color %s: %w", match.Value, err)
+		}
+
+		name, _ := json.Marshal(TokenName(match.Variable))
+		hex, _ := json.Marshal(value)
+		description, _ := json.Marshal(usageDescription(matches, match.Variable))
+
+		buf.WriteString(fmt.Sprintf("    %s: {\n", name))
+		buf.WriteString("      \"$type\": \"color\",\n")
+		buf.WriteString(fmt.Sprintf("      \"$value\": %s,\n", hex))
+		buf.WriteString(fmt.Sprintf("      \"$description\": %s\n", description))
+		buf.WriteString("    }")
+		if i < len(unique)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("  }\n}\n")
+
+	return buf.Bytes(), nil
+}
+
+// TokenName returns the design token name for a custom property, e.g.
+// "text-primary" for "--color-text-primary".
+func TokenName(variable string) string {
+	name := strings.TrimPrefix(variable, "--")
+	if trimmed := strings.TrimPrefix(name, "color-"); trimmed != "" {
+		name = trimmed
+	}
+	return name
+}
+
+// checkKeys returns an error when two variables map to the same key in an
+// output, where one would silently replace the other. what names the kind
+// of key for the message, e.g. "token".
+func checkKeys(unique []colors.ColorMatch, key func(variable string) string, what string) error {
+	owners := make(map[string]string, len(unique))
+	for _, match := range unique {
+		k := key(match.Variable)
+		if owner, ok := owners[k]; ok {
+			return fmt.Errorf("%s and %s both map to the %s %q", owner, match.Variable, what, k)
+		}
+		owners[k] = match.Variable
+	}
+	return nil
+}
+
+// usageDescription summarizes where a variable's colors are used, e.g.
+// "Used 3 times in color, border-color".
+func usageDescription(matches []colors.ColorMatch, variable string) string {
+	count, properties := usage(matches, variable)
+
+	description := fmt.Sprintf("Used %d times", count)
+	if count == 1 {
+		description = "Used once"
+	}
+	if len(properties) > 0 {
+		description += " in " + strings.Join(properties, ", ")
+	}
+	return description
+}
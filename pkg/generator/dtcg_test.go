@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"css-color-variable-creator/pkg/colors"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderDTCGFile(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{
+			Original: "#FF0000",
+			Variable: "--color-text-primary",
+			Value:    "#FF0000",
+			Occurrences: []colors.Occurrence{
+				{Line: 1, Property: "color"},
+				{Line: 4, Property: "border-color"},
+				{Line: 7, Property: "color"},
+			},
+		},
+		{
+			Original:    "rgba(0, 0, 255, 0.5)",
+			Variable:    "--brand-overlay",
+			Value:       "rgba(0, 0, 255, 0.5)",
+			Occurrences: []colors.Occurrence{{Line: 2, Property: "background"}},
+		},
+		{
+			Original:    "#ff0000",
+			Variable:    "--color-text-primary",
+			Value:       "#ff0000",
+			Occurrences: []colors.Occurrence{{Line: 9, Property: "fill"}},
+		},
+	}
+
+	content, err := RenderDTCGFile(matches)
+	if err != nil {
+		t.Fatalf("RenderDTCGFile() error = %v", err)
+	}
+
+	expected := `{
+  "color": {
+    "text-primary": {
+      "$type": "color",
+      "$value": "#ff0000",
+      "$description": "Used 4 times in color, border-color, fill"
+    },
+    "brand-overlay": {
+      "$type": "color",
+      "$value": "#0000ff80",
+      "$description": "Used once in background"
+    }
+  }
+}
+`
+	if string(content) != expected {
+		t.Errorf("Generated file content = %v, want %v", string(content), expected)
+	}
+
+	var doc map[string]map[string]map[string]string
+	if err := json.Unmarshal(content, &doc); err != nil {
+		t.Errorf("Generated file is not valid JSON: %v", err)
+	}
+
+	collision := []colors.ColorMatch{
+		{Variable: "--color-red", Value: "#ff0000"},
+		{Variable: "--red", Value: "#ee0000"},
+	}
+	_, err = RenderDTCGFile(collision)
+	if err == nil || !strings.Contains(err.Error(), `--color-red and --red both map to the token "red"`) {
+		t.Errorf("RenderDTCGFile() error = %v, want token collision", err)
+	}
+}
+
+func TestTokenName(t *testing.T) {
+	tests := []struct {
+		variable string
+		want     string
+	}{
+		{"--color-ff0000", "ff0000"},
+		{"--color-text-primary", "text-primary"},
+		{"--brand-red", "brand-red"},
+		{"--color-", "color-"},
+	}
+
+	for _, tt := range tests {
+		if got := TokenName(tt.variable); got != tt.want {
+			t.Errorf("TokenName(%q) = %q, want %q", tt.variable, got, tt.want)
+		}
+	}
+}
@@ -2,15 +2,49 @@ package generator
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"css-color-variable-creator/pkg/colors"
 )
 
-func GenerateVariablesFile(matches []colors.ColorMatch, outputPath string) error {
+type VariablesOptions struct {
+	Themes Themes
+	// Format is the kind of variables written: "css" (default) for custom
+	// properties, "sass" for a partial of !default Sass variables, or
+	// "sass-css" for Sass variables plus custom properties defined from them.
+	Format string
+	// Sort orders the variables: "appearance" (default, first seen), "hue",
+	// "lightness" (light to dark), "usage" (most used first) or "name".
+	Sort string
+	// Group splits the variables into commented sections by hue family,
+	// with translucent variants under their opaque color.
+	Group bool
+	// Annotate adds a comment with usage metadata to every declaration:
+	// "none" (default), "uses" for the usage count, or "full" for the count,
+	// the properties and the first location, e.g.
+	// "/* 42 uses: color, border-color; style.css:12 */".
+	Annotate string
+	// Source is the scanned file named in "full" annotations.
+	Source string
+	// Scope is the selector the custom properties are declared on: ":root"
+	// (default), ":host" for web components, or a selector such as
+	// ".app-foo". Theme selectors are scoped to it.
+	Scope string
+	// Layer wraps the custom properties in "@layer <Layer> { ... }" when
+	// set.
+	Layer string
+}
+
+func GenerateVariablesFile(matches []colors.ColorMatch, outputPath string, opts VariablesOptions) error {
+	if err := validateVariablesOptions(matches, opts); err != nil {
+		return err
+	}
+
 	file, err := os.Create(outputPath)
 	if err != nil {
 		return fmt.Errorf("failed to create variables file: %w", err)
@@ -18,17 +52,170 @@ func GenerateVariablesFile(matches []colors.ColorMatch, outputPath string) error
 	defer file.Close()
 
 	writer := bufio.NewWriter(file)
+	if err := writeVariables(writer, matches, opts); err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+// RenderVariablesFile returns the content GenerateVariablesFile would write
+// without writing it.
+func RenderVariablesFile(matches []colors.ColorMatch, opts VariablesOptions) ([]byte, error) {
+	if err := validateVariablesOptions(matches, opts); err != nil {
+		return nil, err
+	}
+
+	var output bytes.Buffer
+	writer := bufio.NewWriter(&output)
+	if err := writeVariables(writer, matches, opts); err != nil {
+		return nil, err
+	}
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
+
+func validateVariablesOptions(matches []colors.ColorMatch, opts VariablesOptions) error {
+	if err := validateSort(opts.Sort); err != nil {
+		return err
+	}
+	switch opts.Annotate {
+	case "", "none", "uses", "full":
+	default:
+		return fmt.Errorf("unsupported annotation level: %s", opts.Annotate)
+	}
+
+	if err := validateScope(opts.Scope, opts.Layer); err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case "", "css":
+	case "sass-css":
+		if err := validateSassNames(matches); err != nil {
+			return err
+		}
+	case "sass":
+		if err := validateSassNames(matches); err != nil {
+			return err
+		}
+		if len(opts.Themes) > 0 {
+			return fmt.Errorf("themes need custom properties and cannot be used with sass variables only")
+		}
+		if (opts.Scope != "" && opts.Scope != ":root") || opts.Layer != "" {
+			return fmt.Errorf("a scope or layer needs custom properties and cannot be used with sass variables only")
+		}
+	default:
+		return fmt.Errorf("unsupported variables format: %s", opts.Format)
+	}
+
+	return ValidateThemes(matches, opts.Themes)
+}
+
+func writeVariables(writer *bufio.Writer, matches []colors.ColorMatch, opts VariablesOptions) error {
+	sections := orderVariables(matches, opts)
+	comments := annotations(matches, opts)
+
+	values := make(map[string]string, len(matches))
+	for _, match := range uniqueVariables(matches) {
+		values[match.Variable] = match.Value
+	}
+
+	if opts.Format == "sass" || opts.Format == "sass-css" {
+		for i, section := range sections {
+			if err := writeSectionComment(writer, "// %s\n", section.Title, i > 0); err != nil {
+				return err
+			}
+			for _, match := range section.Matches {
+				line := fmt.Sprintf("%s: %s !default;", SassVariableName(match.Variable), match.Value)
+				if comment := comments[match.Variable]; comment != "" {
+					line += " // " + comment
+				}
+				_, err := writer.WriteString(line + "\n")
+				if err != nil {
+					return fmt.Errorf("failed to write to file: %w", err)
+				}
+			}
+		}
+		if opts.Format == "sass" {
+			return nil
+		}
 
-	_, err = writer.WriteString(":root {\n")
+		// Define the custom properties from the Sass variables
+		for variable := range values {
+			values[variable] = fmt.Sprintf("#{%s}", SassVariableName(variable))
+		}
+		_, err := writer.WriteString("\n")
+		if err != nil {
+			return fmt.Errorf("failed to write to file: %w", err)
+		}
+
+		// The Sass variables carry the annotations
+		comments = nil
+	}
+
+	scope := opts.Scope
+	if scope == "" {
+		scope = ":root"
+	}
+
+	// Render the blocks first so they can be indented into a layer
+	var blocks bytes.Buffer
+	blockWriter := bufio.NewWriter(&blocks)
+	err := writeBlock(blockWriter, scope, sections, values, comments)
 	if err != nil {
-		return fmt.Errorf("failed to write to file: %w", err)
+		return err
 	}
 
-	for _, match := range matches {
-		_, err = writer.WriteString(fmt.Sprintf("  %s: %s;\n", match.Variable, match.Value))
+	for _, name := range opts.Themes.Names() {
+		_, err = blockWriter.WriteString("\n")
 		if err != nil {
 			return fmt.Errorf("failed to write to file: %w", err)
 		}
+
+		err = writeBlock(blockWriter, themeSelector(scope, name), sections, opts.Themes[name], nil)
+		if err != nil {
+			return err
+		}
+	}
+	if err := blockWriter.Flush(); err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+
+	content := blocks.String()
+	if opts.Layer != "" {
+		content = fmt.Sprintf("@layer %s {\n%s}\n", opts.Layer, indent(content))
+	}
+	if _, err := writer.WriteString(content); err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+
+	return nil
+}
+
+func writeBlock(writer *bufio.Writer, selector string, sections []variableSection, values, comments map[string]string) error {
+	_, err := writer.WriteString(selector + " {\n")
+	if err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+
+	for i, section := range sections {
+		if err := writeSectionComment(writer, "  /* %s */\n", section.Title, i > 0); err != nil {
+			return err
+		}
+		for _, match := range section.Matches {
+			line := fmt.Sprintf("  %s: %s;", match.Variable, values[match.Variable])
+			if comment := comments[match.Variable]; comment != "" {
+				line += " /* " + comment + " */"
+			}
+			_, err = writer.WriteString(line + "\n")
+			if err != nil {
+				return fmt.Errorf("failed to write to file: %w", err)
+			}
+		}
 	}
 
 	_, err = writer.WriteString("}\n")
@@ -36,54 +223,249 @@ func GenerateVariablesFile(matches []colors.ColorMatch, outputPath string) error
 		return fmt.Errorf("failed to write to file: %w", err)
 	}
 
-	return writer.Flush()
+	return nil
 }
 
-func GenerateModifiedFile(inputPath string, matches []colors.ColorMatch, outputPath string) error {
-	input, err := os.Open(inputPath)
-	if err != nil {
-		return fmt.Errorf("failed to open input file: %w", err)
+// indent indents every non-empty line of text by two spaces.
+func indent(text string) string {
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = "  " + line
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+var layerNamePattern = regexp.MustCompile(`^[A-Za-z_-][A-Za-z0-9_-]*(\.[A-Za-z_-][A-Za-z0-9_-]*)*$`)
+
+// validateScope checks that scope is a single selector and layer a valid
+// cascade layer name such as "tokens" or "design.tokens".
+func validateScope(scope, layer string) error {
+	if scope != "" && (strings.TrimSpace(scope) != scope || strings.ContainsAny(scope, ",{};")) {
+		return fmt.Errorf("invalid scope %q: must be a single selector such as :root, :host or .app", scope)
+	}
+	if layer != "" && !layerNamePattern.MatchString(layer) {
+		return fmt.Errorf("invalid layer name %q", layer)
+	}
+	return nil
+}
+
+// writeSectionComment writes the comment that starts a section, after a
+// blank line unless it is the first one. Untitled sections get no comment.
+func writeSectionComment(writer *bufio.Writer, format, title string, blankLine bool) error {
+	if title == "" {
+		return nil
+	}
+	comment := fmt.Sprintf(format, title)
+	if blankLine {
+		comment = "\n" + comment
+	}
+	if _, err := writer.WriteString(comment); err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+	return nil
+}
+
+// SassVariableName returns the Sass variable for a custom property, e.g.
+// "$color-ff0000" for "--color-ff0000".
+func SassVariableName(variable string) string {
+	return "$" + strings.TrimPrefix(variable, "--")
+}
+
+// validateSassNames checks that every variable maps to a valid Sass
+// variable; "--1x" is a custom property but "$1x" is not a Sass variable.
+func validateSassNames(matches []colors.ColorMatch) error {
+	for _, match := range matches {
+		if !colors.IsIdentifier(strings.TrimPrefix(match.Variable, "--")) {
+			return fmt.Errorf("%s is not a valid Sass variable name for %s", SassVariableName(match.Variable), match.Variable)
+		}
+	}
+	return nil
+}
+
+// uniqueVariables returns the first match for every variable name, as
+// matches of the same color (e.g. "#FFF" and "#fff") may share a variable.
+func uniqueVariables(matches []colors.ColorMatch) []colors.ColorMatch {
+	seen := make(map[string]bool, len(matches))
+	var unique []colors.ColorMatch
+	for _, match := range matches {
+		if !seen[match.Variable] {
+			seen[match.Variable] = true
+			unique = append(unique, match)
+		}
 	}
-	defer input.Close()
+	return unique
+}
+
+// ModifiedOptions configures how the modified file references the
+// variables file.
+type ModifiedOptions struct {
+	// VariablesPath is the variables file that SCSS output loads. When empty
+	// "<output-base>-variables" next to the output is assumed.
+	VariablesPath string
+	// SCSSImport is the rule used to load the variables file in SCSS
+	// output: "use" (default), "import", or "none" when there is no variables
+	// file to load.
+	SCSSImport string
+	// Reference is how colors are replaced: "var" (default) for
+	// var(--color-x) or "sass" for $color-x.
+	Reference string
+	// Fallback adds the color as var() fallback: var(--color-x, #ff0000).
+	Fallback bool
+	// LegacyFallback keeps a copy of each rewritten declaration with the
+	// literal color in front of it, for browsers without custom properties.
+	LegacyFallback bool
+}
 
-	output, err := os.Create(outputPath)
+func GenerateModifiedFile(inputPath string, matches []colors.ColorMatch, outputPath string, opts ModifiedOptions) error {
+	content, err := RenderModifiedFile(inputPath, matches, outputPath, opts)
 	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, content, 0644); err != nil {
 		return fmt.Errorf("failed to create output file: %w", err)
 	}
-	defer output.Close()
+
+	return nil
+}
+
+// RenderModifiedFile returns the content GenerateModifiedFile would write to
+// outputPath without writing it. Everything but the replaced colors is kept
+// byte for byte, including line endings, a UTF-8 BOM and a missing newline
+// at the end of the file.
+func RenderModifiedFile(inputPath string, matches []colors.ColorMatch, outputPath string, opts ModifiedOptions) ([]byte, error) {
+	input, err := os.ReadFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input file: %w", err)
+	}
+
+	switch opts.SCSSImport {
+	case "", "use", "import", "none":
+	default:
+		return nil, fmt.Errorf("unsupported SCSS import rule: %s", opts.SCSSImport)
+	}
 
 	replacements := make(map[string]string)
 	for _, match := range matches {
-		replacements[match.Original] = fmt.Sprintf("var(%s)", match.Variable)
+		switch opts.Reference {
+		case "", "var":
+			if opts.Fallback {
+				replacements[match.Original] = fmt.Sprintf("var(%s, %s)", match.Variable, match.Value)
+			} else {
+				replacements[match.Original] = fmt.Sprintf("var(%s)", match.Variable)
+			}
+		case "sass":
+			if err := validateSassNames([]colors.ColorMatch{match}); err != nil {
+				return nil, err
+			}
+			replacements[match.Original] = SassVariableName(match.Variable)
+		default:
+			return nil, fmt.Errorf("unsupported reference style: %s", opts.Reference)
+		}
 	}
 
-	writer := bufio.NewWriter(output)
-	if strings.HasSuffix(inputPath, ".scss") {
-		baseFileName := filepath.Base(outputPath)
-		baseFileName = strings.TrimSuffix(baseFileName, filepath.Ext(baseFileName))
-		_, err = writer.WriteString(fmt.Sprintf("@import '%s-variables';\n\n", baseFileName))
+	var output bytes.Buffer
+	text := string(input)
+	if strings.HasPrefix(text, utf8BOM) {
+		output.WriteString(utf8BOM)
+		text = strings.TrimPrefix(text, utf8BOM)
+	}
+
+	if strings.HasSuffix(inputPath, ".scss") && opts.SCSSImport != "none" {
+		rule, err := scssLoadRule(outputPath, opts)
 		if err != nil {
-			return fmt.Errorf("failed to write import statement: %w", err)
+			return nil, err
+		}
+
+		newline := lineEnding(text)
+		at := scssPreambleEnd(text)
+		if at == 0 {
+			output.WriteString(rule + newline + newline)
+		} else {
+			output.WriteString(text[:at] + newline + rule)
+			text = text[at:]
 		}
 	}
 
-	scanner := bufio.NewScanner(input)
-	for scanner.Scan() {
-		line := scanner.Text()
+	output.WriteString(rewriteColors(text, replacements, opts.LegacyFallback, strings.HasSuffix(inputPath, ".scss")))
 
-		for original, variable := range replacements {
-			line = strings.ReplaceAll(line, original, variable)
-		}
+	return output.Bytes(), nil
+}
+
+const utf8BOM = "\uFEFF"
 
-		_, err = writer.WriteString(line + "\n")
+// lineEnding returns the line ending used by text, "\r\n" or "\n".
+func lineEnding(text string) string {
+	if i := strings.Index(text, "\n"); i > 0 && text[i-1] == '\r' {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// scssLoadRule returns the @use or @import rule that loads the variables
+// file from outputPath, e.g. "@use '../tokens/colors' as *;".
+func scssLoadRule(outputPath string, opts ModifiedOptions) (string, error) {
+	var path string
+	if opts.VariablesPath == "" {
+		baseFileName := filepath.Base(outputPath)
+		path = strings.TrimSuffix(baseFileName, filepath.Ext(baseFileName)) + "-variables"
+	} else {
+		rel, err := filepath.Rel(filepath.Dir(outputPath), opts.VariablesPath)
 		if err != nil {
-			return fmt.Errorf("failed to write to output file: %w", err)
+			return "", fmt.Errorf("failed to resolve variables file path: %w", err)
 		}
+		dir, file := filepath.Split(filepath.ToSlash(rel))
+		file = strings.TrimSuffix(file, filepath.Ext(file))
+		path = dir + strings.TrimPrefix(file, "_")
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error scanning file: %w", err)
+	switch opts.SCSSImport {
+	case "", "use":
+		return fmt.Sprintf("@use '%s' as *;", path), nil
+	case "import":
+		return fmt.Sprintf("@import '%s';", path), nil
+	default:
+		return "", fmt.Errorf("unsupported SCSS import rule: %s", opts.SCSSImport)
 	}
+}
 
-	return writer.Flush()
+// scssPreambleEnd returns the offset just past the last @charset, @use or
+// @forward rule at the start of text, skipping comments and whitespace. Sass
+// requires these rules to come before any other statement. It returns 0
+// when text has no such rules.
+func scssPreambleEnd(text string) int {
+	end := 0
+	pos := 0
+	for pos < len(text) {
+		rest := text[pos:]
+		trimmed := strings.TrimLeft(rest, " \t\r\n")
+		pos += len(rest) - len(trimmed)
+
+		switch {
+		case strings.HasPrefix(trimmed, "//"):
+			i := strings.Index(trimmed, "\n")
+			if i < 0 {
+				return end
+			}
+			pos += i + 1
+		case strings.HasPrefix(trimmed, "/*"):
+			i := strings.Index(trimmed, "*/")
+			if i < 0 {
+				return end
+			}
+			pos += i + 2
+		case strings.HasPrefix(trimmed, "@charset"), strings.HasPrefix(trimmed, "@use"), strings.HasPrefix(trimmed, "@forward"):
+			i := strings.Index(trimmed, ";")
+			if i < 0 {
+				return end
+			}
+			pos += i + 1
+			end = pos
+		default:
+			return end
+		}
+	}
+	return end
 }
@@ -31,7 +31,7 @@ func TestGenerateVariablesFile(t *testing.T) {
 	}
 
 	outputPath := filepath.Join(tempDir, "variables.css")
-	err = GenerateVariablesFile(matches, outputPath)
+	err = GenerateVariablesFile(matches, outputPath, VariablesOptions{})
 	if err != nil {
 		t.Fatalf("GenerateVariablesFile() error = %v", err)
 	}
@@ -51,6 +51,104 @@ func TestGenerateVariablesFile(t *testing.T) {
 	}
 }
 
+func TestGenerateVariablesFile_Themes(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "css-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	matches := []colors.ColorMatch{
+		{
+			Original: "#ff0000",
+			Variable: "--color-ff0000",
+			Value:    "#ff0000",
+			Line:     1,
+		},
+		{
+			Original: "#ffffff",
+			Variable: "--color-ffffff",
+			Value:    "#ffffff",
+			Line:     2,
+		},
+	}
+
+	opts := VariablesOptions{
+		Themes: Themes{
+			"dark":          {"--color-ff0000": "#aa0000", "--color-ffffff": "#111111"},
+			"high-contrast": {"--color-ff0000": "#ff0000", "--color-ffffff": "#000000"},
+		},
+	}
+
+	outputPath := filepath.Join(tempDir, "variables.css")
+	err = GenerateVariablesFile(matches, outputPath, opts)
+	if err != nil {
+		t.Fatalf("GenerateVariablesFile() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	expected := `:root {
+  --color-ff0000: #ff0000;
+  --color-ffffff: #ffffff;
+}
+
+[data-theme="dark"] {
+  --color-ff0000: #aa0000;
+  --color-ffffff: #111111;
+}
+
+[data-theme="high-contrast"] {
+  --color-ff0000: #ff0000;
+  --color-ffffff: #000000;
+}
+`
+	if string(content) != expected {
+		t.Errorf("Generated file content = %v, want %v", string(content), expected)
+	}
+
+	delete(opts.Themes["dark"], "--color-ffffff")
+	err = GenerateVariablesFile(matches, outputPath, opts)
+	if err == nil || !strings.Contains(err.Error(), "--color-ffffff") {
+		t.Errorf("GenerateVariablesFile() error = %v, want missing variable error", err)
+	}
+}
+
+func TestGenerateVariablesFile_SharedVariable(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "css-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	matches := []colors.ColorMatch{
+		{Original: "#fff", Variable: "--color-white", Value: "#fff"},
+		{Original: "#FFF", Variable: "--color-white", Value: "#FFF"},
+	}
+
+	outputPath := filepath.Join(tempDir, "variables.css")
+	err = GenerateVariablesFile(matches, outputPath, VariablesOptions{})
+	if err != nil {
+		t.Fatalf("GenerateVariablesFile() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	expected := `:root {
+  --color-white: #fff;
+}
+`
+	if string(content) != expected {
+		t.Errorf("Generated file content = %v, want %v", string(content), expected)
+	}
+}
+
 func TestGenerateModifiedFile(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "css-test")
 	if err != nil {
@@ -85,7 +183,7 @@ func TestGenerateModifiedFile(t *testing.T) {
 	}
 
 	outputPath := filepath.Join(tempDir, "output.css")
-	err = GenerateModifiedFile(inputPath, matches, outputPath)
+	err = GenerateModifiedFile(inputPath, matches, outputPath, ModifiedOptions{})
 	if err != nil {
 		t.Fatalf("GenerateModifiedFile() error = %v", err)
 	}
@@ -141,7 +239,7 @@ func TestGenerateModifiedFile_SCSS(t *testing.T) {
 	}
 
 	outputPath := filepath.Join(tempDir, "output.scss")
-	err = GenerateModifiedFile(inputPath, matches, outputPath)
+	err = GenerateModifiedFile(inputPath, matches, outputPath, ModifiedOptions{SCSSImport: "import"})
 	if err != nil {
 		t.Fatalf("GenerateModifiedFile() error = %v", err)
 	}
@@ -167,3 +265,437 @@ func TestGenerateModifiedFile_SCSS(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateModifiedFile_PreservesBytes(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "css-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	matches := []colors.ColorMatch{
+		{Original: "#fff", Variable: "--color-fff", Value: "#fff"},
+		{Original: "#ffffff", Variable: "--color-ffffff", Value: "#ffffff"},
+		{Original: "rgb(0, 255, 0)", Variable: "--color-rgb-0-255-0", Value: "rgb(0, 255, 0)"},
+	}
+
+	tests := []struct {
+		name     string
+		fileName string
+		input    string
+		want     string
+	}{
+		{
+			name:     "crlf line endings",
+			fileName: "crlf.css",
+			input:    ".a {\r\n  color: #fff;\r\n  background: rgb(0, 255, 0);\r\n}\r\n",
+			want:     ".a {\r\n  color: var(--color-fff);\r\n  background: var(--color-rgb-0-255-0);\r\n}\r\n",
+		},
+		{
+			name:     "no trailing newline",
+			fileName: "eof.css",
+			input:    ".a { color: #ffffff }",
+			want:     ".a { color: var(--color-ffffff) }",
+		},
+		{
+			name:     "utf-8 bom",
+			fileName: "bom.css",
+			input:    "\uFEFF.a { color: #fff; }\n",
+			want:     "\uFEFF.a { color: var(--color-fff); }\n",
+		},
+		{
+			name:     "short hex is not replaced inside long hex",
+			fileName: "hex.css",
+			input:    ".a { color: #ffffff; border-color: #fff; }\n",
+			want:     ".a { color: var(--color-ffffff); border-color: var(--color-fff); }\n",
+		},
+		{
+			name:     "scss bom and crlf",
+			fileName: "bom.scss",
+			input:    "\uFEFF$a: #fff;\r\n",
+			want:     "\uFEFF@use 'output-variables' as *;\r\n\r\n$a: var(--color-fff);\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputPath := filepath.Join(tempDir, tt.fileName)
+			if err := os.WriteFile(inputPath, []byte(tt.input), 0644); err != nil {
+				t.Fatalf("Failed to create test input file: %v", err)
+			}
+
+			outputPath := filepath.Join(tempDir, "output"+filepath.Ext(tt.fileName))
+			if err := GenerateModifiedFile(inputPath, matches, outputPath, ModifiedOptions{}); err != nil {
+				t.Fatalf("GenerateModifiedFile() error = %v", err)
+			}
+
+			content, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatalf("Failed to read generated file: %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("Generated file content = %q, want %q", content, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateModifiedFile_SCSSModules(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scss-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	matches := []colors.ColorMatch{
+		{Original: "#ff0000", Variable: "--color-ff0000", Value: "#ff0000"},
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		opts  ModifiedOptions
+		want  string
+	}{
+		{
+			name:  "use rule with custom variables path",
+			input: ".a { color: #ff0000; }\n",
+			opts: ModifiedOptions{
+				VariablesPath: filepath.Join(tempDir, "tokens", "_colors.scss"),
+			},
+			want: "@use 'tokens/colors' as *;\n\n.a { color: var(--color-ff0000); }\n",
+		},
+		{
+			name:  "import rule in parent directory",
+			input: ".a { color: #ff0000; }\n",
+			opts: ModifiedOptions{
+				VariablesPath: filepath.Join(filepath.Dir(tempDir), "shared-variables.scss"),
+				SCSSImport:    "import",
+			},
+			want: "@import '../shared-variables';\n\n.a { color: var(--color-ff0000); }\n",
+		},
+		{
+			name:  "after charset, use and forward rules",
+			input: "@charset \"UTF-8\";\n// modules\n@use 'sass:math';\n@forward 'mixins';\n\n.a { color: #ff0000; }\n",
+			opts: ModifiedOptions{
+				VariablesPath: filepath.Join(tempDir, "colors.scss"),
+			},
+			want: "@charset \"UTF-8\";\n// modules\n@use 'sass:math';\n@forward 'mixins';\n@use 'colors' as *;\n\n.a { color: var(--color-ff0000); }\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputPath := filepath.Join(tempDir, "input.scss")
+			if err := os.WriteFile(inputPath, []byte(tt.input), 0644); err != nil {
+				t.Fatalf("Failed to create test input file: %v", err)
+			}
+
+			content, err := RenderModifiedFile(inputPath, matches, filepath.Join(tempDir, "output.scss"), tt.opts)
+			if err != nil {
+				t.Fatalf("RenderModifiedFile() error = %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("Rendered content = %q, want %q", content, tt.want)
+			}
+		})
+	}
+
+	inputPath := filepath.Join(tempDir, "input.scss")
+	_, err = RenderModifiedFile(inputPath, matches, inputPath, ModifiedOptions{SCSSImport: "require"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported SCSS import rule") {
+		t.Errorf("RenderModifiedFile() error = %v, want unsupported SCSS import rule", err)
+	}
+}
+
+func TestGenerateVariablesFile_Sass(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{Original: "#ff0000", Variable: "--color-ff0000", Value: "#ff0000"},
+		{Original: "rgb(0, 255, 0)", Variable: "--color-rgb-0-255-0", Value: "rgb(0, 255, 0)"},
+	}
+
+	tests := []struct {
+		name string
+		opts VariablesOptions
+		want string
+	}{
+		{
+			name: "sass variables",
+			opts: VariablesOptions{Format: "sass"},
+			want: `$color-ff0000: #ff0000 !default;
+$color-rgb-0-255-0: rgb(0, 255, 0) !default;
+`,
+		},
+		{
+			name: "sass variables and custom properties",
+			opts: VariablesOptions{Format: "sass-css"},
+			want: `$color-ff0000: #ff0000 !default;
+$color-rgb-0-255-0: rgb(0, 255, 0) !default;
+
+:root {
+  --color-ff0000: #{$color-ff0000};
+  --color-rgb-0-255-0: #{$color-rgb-0-255-0};
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := RenderVariablesFile(matches, tt.opts)
+			if err != nil {
+				t.Fatalf("RenderVariablesFile() error = %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("Rendered content = %v, want %v", string(content), tt.want)
+			}
+		})
+	}
+
+	_, err := RenderVariablesFile(matches, VariablesOptions{Format: "less"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported variables format") {
+		t.Errorf("RenderVariablesFile() error = %v, want unsupported variables format", err)
+	}
+
+	themes := Themes{"dark": {"--color-ff0000": "#000", "--color-rgb-0-255-0": "#000"}}
+	_, err = RenderVariablesFile(matches, VariablesOptions{Format: "sass", Themes: themes})
+	if err == nil {
+		t.Error("RenderVariablesFile() expected error for themes with sass variables only")
+	}
+
+	digit := []colors.ColorMatch{{Variable: "--1x", Value: "#ff0000"}}
+	for _, format := range []string{"sass", "sass-css"} {
+		_, err = RenderVariablesFile(digit, VariablesOptions{Format: format})
+		if err == nil || !strings.Contains(err.Error(), "$1x is not a valid Sass variable name") {
+			t.Errorf("RenderVariablesFile(%s) error = %v, want invalid Sass variable name", format, err)
+		}
+	}
+	if _, err := RenderVariablesFile(digit, VariablesOptions{}); err != nil {
+		t.Errorf("RenderVariablesFile() error = %v for a valid custom property", err)
+	}
+}
+
+func TestGenerateModifiedFile_SassReferences(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scss-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputPath := filepath.Join(tempDir, "input.scss")
+	err = os.WriteFile(inputPath, []byte(".a { color: #ff0000; }\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	matches := []colors.ColorMatch{
+		{Original: "#ff0000", Variable: "--color-ff0000", Value: "#ff0000"},
+	}
+	opts := ModifiedOptions{
+		VariablesPath: filepath.Join(tempDir, "_colors.scss"),
+		Reference:     "sass",
+	}
+
+	content, err := RenderModifiedFile(inputPath, matches, filepath.Join(tempDir, "output.scss"), opts)
+	if err != nil {
+		t.Fatalf("RenderModifiedFile() error = %v", err)
+	}
+
+	expected := "@use 'colors' as *;\n\n.a { color: $color-ff0000; }\n"
+	if string(content) != expected {
+		t.Errorf("Rendered content = %q, want %q", content, expected)
+	}
+}
+
+func TestGenerateModifiedFile_Fallback(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "css-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputPath := filepath.Join(tempDir, "input.css")
+	err = os.WriteFile(inputPath, []byte(".a {\n  color: #FF0000;\n}\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	matches := []colors.ColorMatch{
+		{Original: "#FF0000", Variable: "--color-red", Value: "rgb(255, 0, 0)"},
+	}
+
+	tests := []struct {
+		name string
+		opts ModifiedOptions
+		want string
+	}{
+		{
+			name: "var fallback",
+			opts: ModifiedOptions{Fallback: true},
+			want: ".a {\n  color: var(--color-red, rgb(255, 0, 0));\n}\n",
+		},
+		{
+			name: "legacy fallback",
+			opts: ModifiedOptions{LegacyFallback: true},
+			want: ".a {\n  color: #FF0000;\n  color: var(--color-red);\n}\n",
+		},
+		{
+			name: "both",
+			opts: ModifiedOptions{Fallback: true, LegacyFallback: true},
+			want: ".a {\n  color: #FF0000;\n  color: var(--color-red, rgb(255, 0, 0));\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := RenderModifiedFile(inputPath, matches, filepath.Join(tempDir, "output.css"), tt.opts)
+			if err != nil {
+				t.Fatalf("RenderModifiedFile() error = %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("Rendered content = %q, want %q", content, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateVariablesFile_Annotate(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{
+			Original: "#333",
+			Variable: "--color-x",
+			Value:    "#333",
+			Line:     12,
+			Occurrences: []colors.Occurrence{
+				{Line: 12, Property: "color"},
+				{Line: 14, Property: "border-color"},
+				{Line: 20, Property: "color"},
+			},
+		},
+		{
+			Original:    "#fff",
+			Variable:    "--color-y",
+			Value:       "#fff",
+			Line:        13,
+			Occurrences: []colors.Occurrence{{Line: 13}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts VariablesOptions
+		want string
+	}{
+		{
+			name: "none",
+			opts: VariablesOptions{Annotate: "none", Source: "style.css"},
+			want: ":root {\n  --color-x: #333;\n  --color-y: #fff;\n}\n",
+		},
+		{
+			name: "uses",
+			opts: VariablesOptions{Annotate: "uses"},
+			want: ":root {\n  --color-x: #333; /* 3 uses */\n  --color-y: #fff; /* 1 use */\n}\n",
+		},
+		{
+			name: "full",
+			opts: VariablesOptions{Annotate: "full", Source: "style.css"},
+			want: ":root {\n  --color-x: #333; /* 3 uses: color, border-color; style.css:12 */\n  --color-y: #fff; /* 1 use; style.css:13 */\n}\n",
+		},
+		{
+			name: "sass",
+			opts: VariablesOptions{Format: "sass-css", Annotate: "uses"},
+			want: "$color-x: #333 !default; // 3 uses\n$color-y: #fff !default; // 1 use\n\n:root {\n  --color-x: #{$color-x};\n  --color-y: #{$color-y};\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := RenderVariablesFile(matches, tt.opts)
+			if err != nil {
+				t.Fatalf("RenderVariablesFile() error = %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("Generated file content = %q, want %q", string(content), tt.want)
+			}
+		})
+	}
+
+	if _, err := RenderVariablesFile(matches, VariablesOptions{Annotate: "verbose"}); err == nil {
+		t.Error("RenderVariablesFile() with an unknown annotation level expected error")
+	}
+}
+
+func TestGenerateVariablesFile_Scope(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{Original: "#fff", Variable: "--color-bg", Value: "#fff"},
+	}
+	themes := Themes{"dark": {"--color-bg": "#000"}}
+
+	tests := []struct {
+		name string
+		opts VariablesOptions
+		want string
+	}{
+		{
+			name: "root",
+			opts: VariablesOptions{Scope: ":root", Themes: themes},
+			want: ":root {\n  --color-bg: #fff;\n}\n\n[data-theme=\"dark\"] {\n  --color-bg: #000;\n}\n",
+		},
+		{
+			name: "host",
+			opts: VariablesOptions{Scope: ":host", Themes: themes},
+			want: ":host {\n  --color-bg: #fff;\n}\n\n:host([data-theme=\"dark\"]) {\n  --color-bg: #000;\n}\n",
+		},
+		{
+			name: "class",
+			opts: VariablesOptions{Scope: ".app-foo", Themes: themes},
+			want: ".app-foo {\n  --color-bg: #fff;\n}\n\n.app-foo[data-theme=\"dark\"] {\n  --color-bg: #000;\n}\n",
+		},
+		{
+			name: "host function",
+			opts: VariablesOptions{Scope: ":host(.compact)", Themes: themes},
+			want: ":host(.compact) {\n  --color-bg: #fff;\n}\n\n:host(.compact[data-theme=\"dark\"]) {\n  --color-bg: #000;\n}\n",
+		},
+		{
+			name: "descendant",
+			opts: VariablesOptions{Scope: ".app .widget", Themes: themes},
+			want: ".app .widget {\n  --color-bg: #fff;\n}\n\n[data-theme=\"dark\"] .app .widget {\n  --color-bg: #000;\n}\n",
+		},
+		{
+			name: "layer",
+			opts: VariablesOptions{Layer: "tokens", Themes: themes},
+			want: "@layer tokens {\n  :root {\n    --color-bg: #fff;\n  }\n\n  [data-theme=\"dark\"] {\n    --color-bg: #000;\n  }\n}\n",
+		},
+		{
+			name: "sass layer",
+			opts: VariablesOptions{Format: "sass-css", Scope: ":host", Layer: "design.tokens"},
+			want: "$color-bg: #fff !default;\n\n@layer design.tokens {\n  :host {\n    --color-bg: #{$color-bg};\n  }\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := RenderVariablesFile(matches, tt.opts)
+			if err != nil {
+				t.Fatalf("RenderVariablesFile() error = %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("Generated file content = %q, want %q", string(content), tt.want)
+			}
+		})
+	}
+
+	invalid := []VariablesOptions{
+		{Scope: ".a, .b"},
+		{Scope: ".a { color: red }"},
+		{Layer: "my layer"},
+		{Layer: "tokens."},
+		{Format: "sass", Layer: "tokens"},
+		{Format: "sass", Scope: ":host"},
+	}
+	for _, opts := range invalid {
+		if _, err := RenderVariablesFile(matches, opts); err == nil {
+			t.Errorf("RenderVariablesFile() with %+v expected error", opts)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+var jsIdentifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// RenderJSModule returns an ES module exporting the palette as
+//
+//	export const colors = {
+//	  brand: { variable: '--color-brand', value: '#ff0000' },
+//	}
+//
+// With typescript set, the object is declared `as const` and the module also
+// exports the types RenderTypeDeclarations declares.
+func RenderJSModule(matches []colors.ColorMatch, typescript bool) ([]byte, error) {
+	unique := uniqueVariables(matches)
+	if err := checkKeys(unique, jsKey, "JavaScript key"); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("export const colors = {\n")
+	for _, match := range unique {
+		buf.WriteString(fmt.Sprintf("  %s: { variable: %s, value: %s },\n",
+			jsKey(match.Variable), jsString(match.Variable), jsString(match.Value)))
+	}
+	buf.WriteString("}")
+
+	if typescript {
+		buf.WriteString(" as const\n\n")
+		buf.WriteString("export type ColorName = keyof typeof colors\n")
+		writeVariableTypes(&buf, matches)
+	} else {
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// RenderTypeDeclarations returns the .d.ts for the module RenderJSModule
+// writes without typescript: the exact type of colors, a ColorVariable
+// union of all custom property names and a CSSVar type of their var()
+// references.
+func RenderTypeDeclarations(matches []colors.ColorMatch) ([]byte, error) {
+	unique := uniqueVariables(matches)
+	if err := checkKeys(unique, jsKey, "JavaScript key"); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("export declare const colors: {\n")
+	for _, match := range unique {
+		buf.WriteString(fmt.Sprintf("  readonly %s: { readonly variable: %s; readonly value: %s };\n",
+			jsKey(match.Variable), jsString(match.Variable), jsString(match.Value)))
+	}
+	buf.WriteString("}\n\n")
+	buf.WriteString("export type ColorName = keyof typeof colors\n")
+	writeVariableTypes(&buf, matches)
+
+	return buf.Bytes(), nil
+}
+
+func writeVariableTypes(buf *bytes.Buffer, matches []colors.ColorMatch) {
+	var variables []string
+	for _, match := range uniqueVariables(matches) {
+		variables = append(variables, jsString(match.Variable))
+	}
+	buf.WriteString("export type ColorVariable =\n")
+	for _, variable := range variables {
+		buf.WriteString("  | " + variable + "\n")
+	}
+	buf.WriteString("export type CSSVar = `var(${ColorVariable})`\n")
+}
+
+// jsKey returns the object key for a variable: its token name in camel case,
+// e.g. textPrimary for --color-text-primary, quoted when it is not a valid
+// identifier. Digit runs keep a "_" between them, so rgb-25-5-0 and
+// rgb-2-55-0 become rgb25_5_0 and rgb2_55_0.
+func jsKey(variable string) string {
+	key := camelCase(TokenName(variable))
+	if jsIdentifierRegex.MatchString(key) {
+		return key
+	}
+	return jsString(key)
+}
+
+// camelCase joins the hyphen separated parts of name in camel case, with a
+// "_" where two digit runs would otherwise merge.
+func camelCase(name string) string {
+	parts := strings.Split(name, "-")
+	key := parts[0]
+	for _, part := range parts[1:] {
+		if part == "" {
+			continue
+		}
+		if endsWithDigit(key) && isDigit(part[0]) {
+			key += "_"
+		}
+		key += strings.ToUpper(part[:1]) + part[1:]
+	}
+	return key
+}
+
+func endsWithDigit(s string) bool {
+	return s != "" && isDigit(s[len(s)-1])
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
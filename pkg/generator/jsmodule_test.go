@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"css-color-variable-creator/pkg/colors"
+	"strings"
+	"testing"
+)
+
+func TestRenderJSModule(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{Original: "#ff0000", Variable: "--color-text-primary", Value: "#ff0000"},
+		{Original: "rgb(0, 255, 0)", Variable: "--color-00ff00", Value: "rgb(0, 255, 0)"},
+	}
+
+	content, err := RenderJSModule(matches, false)
+	if err != nil {
+		t.Fatalf("RenderJSModule() error = %v", err)
+	}
+	got := string(content)
+	want := `export const colors = {
+  textPrimary: { variable: '--color-text-primary', value: '#ff0000' },
+  '00ff00': { variable: '--color-00ff00', value: 'rgb(0, 255, 0)' },
+}
+`
+	if got != want {
+		t.Errorf("RenderJSModule() = %v, want %v", got, want)
+	}
+
+	content, err = RenderJSModule(matches, true)
+	if err != nil {
+		t.Fatalf("RenderJSModule() error = %v", err)
+	}
+	got = string(content)
+	want = `export const colors = {
+  textPrimary: { variable: '--color-text-primary', value: '#ff0000' },
+  '00ff00': { variable: '--color-00ff00', value: 'rgb(0, 255, 0)' },
+} as const
+
+export type ColorName = keyof typeof colors
+export type ColorVariable =
+  | '--color-text-primary'
+  | '--color-00ff00'
+export type CSSVar = ` + "`var(${ColorVariable})`" + `
+`
+	if got != want {
+		t.Errorf("RenderJSModule() with typescript = %v, want %v", got, want)
+	}
+}
+
+func TestRenderTypeDeclarations(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{Original: "#ff0000", Variable: "--color-brand", Value: "#ff0000"},
+	}
+
+	content, err := RenderTypeDeclarations(matches)
+	if err != nil {
+		t.Fatalf("RenderTypeDeclarations() error = %v", err)
+	}
+	got := string(content)
+	want := `export declare const colors: {
+  readonly brand: { readonly variable: '--color-brand'; readonly value: '#ff0000' };
+}
+
+export type ColorName = keyof typeof colors
+export type ColorVariable =
+  | '--color-brand'
+export type CSSVar = ` + "`var(${ColorVariable})`" + `
+`
+	if got != want {
+		t.Errorf("RenderTypeDeclarations() = %v, want %v", got, want)
+	}
+}
+
+func TestJSKey_Collisions(t *testing.T) {
+	// The default names of rgb(25, 5, 0) and rgb(2, 55, 0)
+	matches := []colors.ColorMatch{
+		{Original: "rgb(25, 5, 0)", Variable: "--color-rgb-25-5-0", Value: "rgb(25, 5, 0)"},
+		{Original: "rgb(2, 55, 0)", Variable: "--color-rgb-2-55-0", Value: "rgb(2, 55, 0)"},
+	}
+
+	content, err := RenderJSModule(matches, true)
+	if err != nil {
+		t.Fatalf("RenderJSModule() error = %v", err)
+	}
+	for _, key := range []string{"  rgb25_5_0: ", "  rgb2_55_0: "} {
+		if !strings.Contains(string(content), key) {
+			t.Errorf("RenderJSModule() = %s, want key %q", content, key)
+		}
+	}
+
+	collision := []colors.ColorMatch{
+		{Variable: "--color-text-primary", Value: "#000000"},
+		{Variable: "--color-textPrimary", Value: "#111111"},
+	}
+	if _, err := RenderJSModule(collision, false); err == nil {
+		t.Error("RenderJSModule() expected error for colliding keys")
+	}
+	if _, err := RenderTypeDeclarations(collision); err == nil {
+		t.Error("RenderTypeDeclarations() expected error for colliding keys")
+	}
+}
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+// variableSection is a run of variables written under an optional comment.
+type variableSection struct {
+	Title   string
+	Matches []colors.ColorMatch
+}
+
+// orderVariables returns the unique variables in the order opts.Sort asks
+// for. With opts.Group they are split into sections by hue family, neutrals
+// first, and translucent variants follow the opaque variable of the same
+// color.
+func orderVariables(matches []colors.ColorMatch, opts VariablesOptions) []variableSection {
+	unique := uniqueVariables(matches)
+	sortVariables(unique, matches, opts.Sort)
+
+	if !opts.Group {
+		return []variableSection{{Matches: unique}}
+	}
+
+	families := make(map[string][]colors.ColorMatch)
+	for _, match := range unique {
+		r, g, b, _ := colors.ParseToRGBA(match.Value)
+		family := colors.HueFamily(r, g, b)
+		families[family] = append(families[family], match)
+	}
+
+	var sections []variableSection
+	for _, family := range colors.HueFamilyNames() {
+		if len(families[family]) == 0 {
+			continue
+		}
+		sections = append(sections, variableSection{
+			Title:   sectionTitle(family),
+			Matches: groupAlphaVariants(families[family]),
+		})
+	}
+	return sections
+}
+
+func validateSort(order string) error {
+	switch order {
+	case "", "appearance", "hue", "lightness", "usage", "name":
+		return nil
+	default:
+		return fmt.Errorf("unsupported sort order: %s", order)
+	}
+}
+
+// sortVariables sorts unique in place. Ties keep the order of appearance.
+func sortVariables(unique []colors.ColorMatch, matches []colors.ColorMatch, order string) {
+	if order == "" || order == "appearance" {
+		return
+	}
+
+	type key struct {
+		neutral        bool
+		lightness, hue float64
+		uses           int
+	}
+	keys := make([]key, len(unique))
+	for i, match := range unique {
+		r, g, b, _ := colors.ParseToRGBA(match.Value)
+		l, _, h := colors.ToOKLCH(r, g, b)
+		uses, _ := usage(matches, match.Variable)
+		keys[i] = key{neutral: colors.HueFamily(r, g, b) == "gray", lightness: l, hue: h, uses: uses}
+	}
+
+	// Sort indexes so keys stay aligned with their matches
+	indexes := make([]int, len(unique))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(x, y int) bool {
+		a, b := keys[indexes[x]], keys[indexes[y]]
+		switch order {
+		case "hue":
+			if a.neutral != b.neutral {
+				return a.neutral
+			}
+			if !a.neutral && a.hue != b.hue {
+				return a.hue < b.hue
+			}
+			return a.lightness > b.lightness
+		case "lightness":
+			return a.lightness > b.lightness
+		case "usage":
+			return a.uses > b.uses
+		default:
+			return unique[indexes[x]].Variable < unique[indexes[y]].Variable
+		}
+	})
+
+	sorted := make([]colors.ColorMatch, len(unique))
+	for i, index := range indexes {
+		sorted[i] = unique[index]
+	}
+	copy(unique, sorted)
+}
+
+// groupAlphaVariants moves every translucent color right after the opaque
+// color with the same RGB, when there is one.
+func groupAlphaVariants(section []colors.ColorMatch) []colors.ColorMatch {
+	rgbKey := func(match colors.ColorMatch) (string, bool) {
+		r, g, b, a := colors.ParseToRGBA(match.Value)
+		return fmt.Sprintf("%d,%d,%d", r, g, b), a < 1
+	}
+
+	bases := make(map[string]bool)
+	for _, match := range section {
+		if key, translucent := rgbKey(match); !translucent {
+			bases[key] = true
+		}
+	}
+
+	variants := make(map[string][]colors.ColorMatch)
+	var rest []colors.ColorMatch
+	for _, match := range section {
+		if key, translucent := rgbKey(match); translucent && bases[key] {
+			variants[key] = append(variants[key], match)
+			continue
+		}
+		rest = append(rest, match)
+	}
+
+	var ordered []colors.ColorMatch
+	for _, match := range rest {
+		ordered = append(ordered, match)
+		if key, translucent := rgbKey(match); !translucent {
+			ordered = append(ordered, variants[key]...)
+			delete(variants, key)
+		}
+	}
+	return ordered
+}
+
+// sectionTitle returns the comment for a hue family, e.g. "Reds".
+func sectionTitle(family string) string {
+	if family == "gray" {
+		return "Neutrals"
+	}
+	return strings.ToUpper(family[:1]) + family[1:] + "s"
+}
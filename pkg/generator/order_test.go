@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"css-color-variable-creator/pkg/colors"
+	"strings"
+	"testing"
+)
+
+func orderMatches() []colors.ColorMatch {
+	return []colors.ColorMatch{
+		{Variable: "--color-blue", Value: "#0000ff", Occurrences: []colors.Occurrence{{Line: 1}}},
+		{Variable: "--color-white", Value: "#ffffff", Occurrences: []colors.Occurrence{{Line: 2}, {Line: 3}}},
+		{Variable: "--color-red-a50", Value: "rgba(255, 0, 0, 0.5)", Occurrences: []colors.Occurrence{{Line: 4}}},
+		{Variable: "--color-black", Value: "#000000", Occurrences: []colors.Occurrence{{Line: 5}, {Line: 6}, {Line: 7}}},
+		{Variable: "--color-red", Value: "#ff0000", Occurrences: []colors.Occurrence{{Line: 8}}},
+	}
+}
+
+func TestOrderVariables_Sort(t *testing.T) {
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"", []string{"--color-blue", "--color-white", "--color-red-a50", "--color-black", "--color-red"}},
+		{"appearance", []string{"--color-blue", "--color-white", "--color-red-a50", "--color-black", "--color-red"}},
+		{"hue", []string{"--color-white", "--color-black", "--color-red-a50", "--color-red", "--color-blue"}},
+		{"lightness", []string{"--color-white", "--color-red-a50", "--color-red", "--color-blue", "--color-black"}},
+		{"usage", []string{"--color-black", "--color-white", "--color-blue", "--color-red-a50", "--color-red"}},
+		{"name", []string{"--color-black", "--color-blue", "--color-red", "--color-red-a50", "--color-white"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			sections := orderVariables(orderMatches(), VariablesOptions{Sort: tt.sort})
+			if len(sections) != 1 || sections[0].Title != "" {
+				t.Fatalf("orderVariables() sections = %+v, want one untitled section", sections)
+			}
+
+			var got []string
+			for _, match := range sections[0].Matches {
+				got = append(got, match.Variable)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("orderVariables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateVariablesFile_Group(t *testing.T) {
+	content, err := RenderVariablesFile(orderMatches(), VariablesOptions{Sort: "lightness", Group: true})
+	if err != nil {
+		t.Fatalf("RenderVariablesFile() error = %v", err)
+	}
+
+	expected := `:root {
+  /* Neutrals */
+  --color-white: #ffffff;
+  --color-black: #000000;
+
+  /* Reds */
+  --color-red: #ff0000;
+  --color-red-a50: rgba(255, 0, 0, 0.5);
+
+  /* Blues */
+  --color-blue: #0000ff;
+}
+`
+	if string(content) != expected {
+		t.Errorf("Generated file content = %v, want %v", string(content), expected)
+	}
+
+	if _, err := RenderVariablesFile(orderMatches(), VariablesOptions{Sort: "random"}); err == nil {
+		t.Error("RenderVariablesFile() with an unknown sort expected error")
+	}
+}
@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"fmt"
+	"unicode/utf16"
+
+	"css-color-variable-creator/pkg/colors"
+	"css-color-variable-creator/pkg/palette"
+)
+
+// RenderGPL returns the palette as a GIMP/Inkscape .gpl file named name.
+// The format has no alpha channel, so translucent colors are written
+// opaque.
+func RenderGPL(matches []colors.ColorMatch, name string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("GIMP Palette\n")
+	buf.WriteString(fmt.Sprintf("Name: %s\n", name))
+	buf.WriteString("Columns: 0\n#\n")
+	for _, match := range uniqueVariables(matches) {
+		r, g, b, _ := colors.ParseToRGBA(match.Value)
+		buf.WriteString(fmt.Sprintf("%3d %3d %3d\t%s\n", r, g, b, TokenName(match.Variable)))
+	}
+	return buf.Bytes()
+}
+
+// RenderASE returns the palette as an Adobe Swatch Exchange file with one
+// RGB swatch per variable. ASE swatches have no alpha channel.
+func RenderASE(matches []colors.ColorMatch) []byte {
+	unique := uniqueVariables(matches)
+
+	var buf bytes.Buffer
+	buf.WriteString("ASEF")
+	binary.Write(&buf, binary.BigEndian, []uint16{1, 0})
+	binary.Write(&buf, binary.BigEndian, uint32(len(unique)))
+
+	for _, match := range unique {
+		name := utf16.Encode([]rune(TokenName(match.Variable) + "\x00"))
+		r, g, b, _ := colors.ParseToRGBA(match.Value)
+
+		var block bytes.Buffer
+		binary.Write(&block, binary.BigEndian, uint16(len(name)))
+		binary.Write(&block, binary.BigEndian, name)
+		block.WriteString("RGB ")
+		binary.Write(&block, binary.BigEndian, []float32{float32(r) / 255, float32(g) / 255, float32(b) / 255})
+		binary.Write(&block, binary.BigEndian, uint16(palette.ASENormalColor))
+
+		binary.Write(&buf, binary.BigEndian, uint16(palette.ASEColorEntry))
+		binary.Write(&buf, binary.BigEndian, uint32(block.Len()))
+		buf.Write(block.Bytes())
+	}
+	return buf.Bytes()
+}
+
+// RenderPaletteCSV returns the palette as CSV with the name, hex, rgb and
+// hsl values, usage count and first location of every variable. source is
+// the scanned file, used for locations like "style.css:12".
+func RenderPaletteCSV(matches []colors.ColorMatch, source string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"name", "hex", "rgb", "hsl", "uses", "first_location"})
+
+	for _, match := range uniqueVariables(matches) {
+		hex, _ := colors.ConvertColor(match.Value, "hex")
+		hsl, _ := colors.ConvertColor(match.Value, "hsl")
+		rgb, _ := colors.ConvertColor(match.Value, "rgb")
+		if _, _, _, a := colors.ParseToRGBA(match.Value); a < 1 {
+			rgb, _ = colors.ConvertColor(match.Value, "rgba")
+		}
+		count, _ := usage(matches, match.Variable)
+
+		writer.Write([]string{
+			TokenName(match.Variable),
+			hex,
+			rgb,
+			hsl,
+			fmt.Sprint(count),
+			fmt.Sprintf("%s:%d", source, firstLine(matches, match.Variable)),
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
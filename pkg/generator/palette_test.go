@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"bytes"
+	"css-color-variable-creator/pkg/colors"
+	"css-color-variable-creator/pkg/palette"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+var paletteMatches = []colors.ColorMatch{
+	{
+		Original:    "#FF0000",
+		Variable:    "--color-brand",
+		Value:       "#FF0000",
+		Line:        3,
+		Occurrences: []colors.Occurrence{{Line: 3}, {Line: 8}},
+	},
+	{
+		Original:    "rgba(0, 0, 255, 0.5)",
+		Variable:    "--color-overlay",
+		Value:       "rgba(0, 0, 255, 0.5)",
+		Line:        5,
+		Occurrences: []colors.Occurrence{{Line: 5}},
+	},
+	{
+		Original:    "#f00",
+		Variable:    "--color-brand",
+		Value:       "#f00",
+		Line:        1,
+		Occurrences: []colors.Occurrence{{Line: 1}},
+	},
+}
+
+func TestRenderGPL(t *testing.T) {
+	got := string(RenderGPL(paletteMatches, "style"))
+	want := "GIMP Palette\nName: style\nColumns: 0\n#\n255   0   0\tbrand\n  0   0 255\toverlay\n"
+	if got != want {
+		t.Errorf("RenderGPL() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderASE(t *testing.T) {
+	data := RenderASE(paletteMatches)
+
+	if string(data[:4]) != "ASEF" {
+		t.Fatalf("RenderASE() signature = %q, want ASEF", data[:4])
+	}
+	if count := binary.BigEndian.Uint32(data[8:12]); count != 2 {
+		t.Fatalf("RenderASE() block count = %d, want 2", count)
+	}
+
+	// First block: type, length, name "brand" in UTF-16 with terminator
+	block := data[12:]
+	if blockType := binary.BigEndian.Uint16(block[0:2]); blockType != palette.ASEColorEntry {
+		t.Errorf("Block type = %#x, want %#x", blockType, palette.ASEColorEntry)
+	}
+	length := binary.BigEndian.Uint32(block[2:6])
+	if nameLength := binary.BigEndian.Uint16(block[6:8]); nameLength != 6 {
+		t.Errorf("Name length = %d, want 6", nameLength)
+	}
+	name := block[8:20]
+	if !bytes.Equal(name, []byte{0, 'b', 0, 'r', 0, 'a', 0, 'n', 0, 'd', 0, 0}) {
+		t.Errorf("Name = %v", name)
+	}
+	if model := string(block[20:24]); model != "RGB " {
+		t.Errorf("Color model = %q, want \"RGB \"", model)
+	}
+	red := math.Float32frombits(binary.BigEndian.Uint32(block[24:28]))
+	if red != 1 {
+		t.Errorf("Red = %v, want 1", red)
+	}
+	// Name length, name, model, three floats and the color type
+	if length != 2+12+4+12+2 {
+		t.Errorf("Block length = %d, want 32", length)
+	}
+	if next := binary.BigEndian.Uint16(block[6+length:]); next != palette.ASEColorEntry {
+		t.Errorf("Second block type = %#x, want %#x", next, palette.ASEColorEntry)
+	}
+}
+
+func TestRenderPaletteCSV(t *testing.T) {
+	got, err := RenderPaletteCSV(paletteMatches, "style.css")
+	if err != nil {
+		t.Fatalf("RenderPaletteCSV() error = %v", err)
+	}
+
+	want := `name,hex,rgb,hsl,uses,first_location
+brand,#ff0000,"rgb(255, 0, 0)","hsl(0, 100%, 50%)",3,style.css:1
+overlay,#0000ff80,"rgba(0, 0, 255, 0.50)","hsla(240, 100%, 50%, 0.50)",1,style.css:5
+`
+	if string(got) != want {
+		t.Errorf("RenderPaletteCSV() = %v, want %v", string(got), want)
+	}
+}
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+// PlatformFile is one file of a native platform export, with Path relative
+// to the export's root directory.
+type PlatformFile struct {
+	Path    string
+	Content []byte
+}
+
+var androidInvalidRegex = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// RenderAndroidResources returns res/values/colors.xml with a <color> per
+// variable in #AARRGGBB form. When dark holds values for the variables, as
+// the "dark" theme does, they are written to values-night/colors.xml.
+func RenderAndroidResources(matches []colors.ColorMatch, dark map[string]string) ([]PlatformFile, error) {
+	if err := checkKeys(uniqueVariables(matches), AndroidResourceName, "Android resource"); err != nil {
+		return nil, err
+	}
+
+	files := []PlatformFile{{Path: "values/colors.xml", Content: androidColors(matches, nil)}}
+	if len(dark) > 0 {
+		if err := validatePlatformValues(matches, dark); err != nil {
+			return nil, err
+		}
+		files = append(files, PlatformFile{Path: "values-night/colors.xml", Content: androidColors(matches, dark)})
+	}
+	return files, nil
+}
+
+func androidColors(matches []colors.ColorMatch, values map[string]string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n")
+	for _, match := range uniqueVariables(matches) {
+		value := match.Value
+		if values != nil {
+			value = values[match.Variable]
+		}
+		r, g, b, a := colors.ParseToRGBA(value)
+		buf.WriteString(fmt.Sprintf("    <color name=\"%s\">#%02X%02X%02X%02X</color>\n",
+			AndroidResourceName(match.Variable), alphaByte(a), r, g, b))
+	}
+	buf.WriteString("</resources>\n")
+	return buf.Bytes()
+}
+
+// AndroidResourceName returns the resource name for a variable, e.g.
+// "text_primary" for --color-text-primary.
+func AndroidResourceName(variable string) string {
+	name := androidInvalidRegex.ReplaceAllString(strings.ToLower(TokenName(variable)), "_")
+	name = strings.Trim(name, "_")
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "color_" + name
+	}
+	return name
+}
+
+// RenderIOSAssetCatalog returns the files of an asset catalog such as
+// Colors.xcassets: the catalog's Contents.json and a color set per variable.
+// Values in dark become the dark appearance of each color.
+func RenderIOSAssetCatalog(matches []colors.ColorMatch, dark map[string]string) ([]PlatformFile, error) {
+	if err := checkKeys(uniqueVariables(matches), IOSColorSetName, "iOS color set"); err != nil {
+		return nil, err
+	}
+	if len(dark) > 0 {
+		if err := validatePlatformValues(matches, dark); err != nil {
+			return nil, err
+		}
+	}
+
+	files := []PlatformFile{{Path: "Contents.json", Content: []byte(xcassetsInfo)}}
+	for _, match := range uniqueVariables(matches) {
+		var buf bytes.Buffer
+		buf.WriteString("{\n  \"colors\" : [\n")
+		writeColorEntry(&buf, match.Value, false)
+		if len(dark) > 0 {
+			buf.WriteString(",\n")
+			writeColorEntry(&buf, dark[match.Variable], true)
+		}
+		buf.WriteString("\n  ],\n  \"info\" : {\n    \"author\" : \"xcode\",\n    \"version\" : 1\n  }\n}\n")
+
+		files = append(files, PlatformFile{
+			Path:    IOSColorSetName(match.Variable) + ".colorset/Contents.json",
+			Content: buf.Bytes(),
+		})
+	}
+	return files, nil
+}
+
+const xcassetsInfo = `{
+  "info" : {
+    "author" : "xcode",
+    "version" : 1
+  }
+}
+`
+
+func writeColorEntry(buf *bytes.Buffer, value string, dark bool) {
+	r, g, b, a := colors.ParseToRGBA(value)
+	buf.WriteString("    {\n")
+	if dark {
+		buf.WriteString("      \"appearances\" : [\n        {\n          \"appearance\" : \"luminosity\",\n          \"value\" : \"dark\"\n        }\n      ],\n")
+	}
+	buf.WriteString("      \"color\" : {\n        \"color-space\" : \"srgb\",\n        \"components\" : {\n")
+	buf.WriteString(fmt.Sprintf("          \"alpha\" : \"%.3f\",\n", a))
+	buf.WriteString(fmt.Sprintf("          \"blue\" : \"0x%02X\",\n", b))
+	buf.WriteString(fmt.Sprintf("          \"green\" : \"0x%02X\",\n", g))
+	buf.WriteString(fmt.Sprintf("          \"red\" : \"0x%02X\"\n", r))
+	buf.WriteString("        }\n      },\n      \"idiom\" : \"universal\"\n    }")
+}
+
+// IOSColorSetName returns the asset name for a variable in camel case, e.g.
+// "textPrimary" for --color-text-primary. Like JavaScript keys, digit runs
+// keep a "_" between them.
+func IOSColorSetName(variable string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || r == '/' || r == '.' {
+			return '-'
+		}
+		return r
+	}, TokenName(variable))
+	name = camelCase(strings.Trim(name, "-"))
+	if name == "" {
+		name = "color"
+	}
+	return name
+}
+
+// validatePlatformValues checks that every variable has a literal color in
+// values, as native platforms cannot resolve CSS references.
+func validatePlatformValues(matches []colors.ColorMatch, values map[string]string) error {
+	for _, match := range uniqueVariables(matches) {
+		if !colors.IsColor(values[match.Variable]) {
+			return fmt.Errorf("dark value for %s must be a hex, rgb() or rgba() color, got %q", match.Variable, values[match.Variable])
+		}
+	}
+	return nil
+}
+
+func alphaByte(a float64) uint8 {
+	return uint8(math.Round(a * 255))
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"css-color-variable-creator/pkg/colors"
+	"encoding/json"
+	"testing"
+)
+
+func TestRenderAndroidResources(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{Original: "#FF0000", Variable: "--color-text-primary", Value: "#FF0000"},
+		{Original: "rgba(0, 0, 0, 0.5)", Variable: "--color-00000080", Value: "rgba(0, 0, 0, 0.5)"},
+	}
+
+	files, err := RenderAndroidResources(matches, nil)
+	if err != nil {
+		t.Fatalf("RenderAndroidResources() error = %v", err)
+	}
+	if len(files) != 1 || files[0].Path != "values/colors.xml" {
+		t.Fatalf("RenderAndroidResources() files = %+v, want values/colors.xml only", files)
+	}
+
+	want := `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <color name="text_primary">#FFFF0000</color>
+    <color name="color_00000080">#80000000</color>
+</resources>
+`
+	if string(files[0].Content) != want {
+		t.Errorf("colors.xml = %v, want %v", string(files[0].Content), want)
+	}
+
+	dark := map[string]string{"--color-text-primary": "#eeeeee", "--color-00000080": "rgba(255, 255, 255, 0.5)"}
+	files, err = RenderAndroidResources(matches, dark)
+	if err != nil {
+		t.Fatalf("RenderAndroidResources() with dark values error = %v", err)
+	}
+	if len(files) != 2 || files[1].Path != "values-night/colors.xml" {
+		t.Fatalf("RenderAndroidResources() files = %+v, want values-night/colors.xml", files)
+	}
+
+	_, err = RenderAndroidResources(matches, map[string]string{"--color-text-primary": "var(--x)"})
+	if err == nil {
+		t.Error("RenderAndroidResources() with non-color dark values expected error")
+	}
+}
+
+func TestRenderIOSAssetCatalog(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{Original: "#FF0000", Variable: "--color-text-primary", Value: "#FF0000"},
+	}
+	dark := map[string]string{"--color-text-primary": "rgba(0, 0, 255, 0.5)"}
+
+	files, err := RenderIOSAssetCatalog(matches, dark)
+	if err != nil {
+		t.Fatalf("RenderIOSAssetCatalog() error = %v", err)
+	}
+	if len(files) != 2 || files[0].Path != "Contents.json" || files[1].Path != "textPrimary.colorset/Contents.json" {
+		t.Fatalf("RenderIOSAssetCatalog() files = %+v", files)
+	}
+
+	var colorSet struct {
+		Colors []struct {
+			Appearances []struct {
+				Appearance string `json:"appearance"`
+				Value      string `json:"value"`
+			} `json:"appearances"`
+			Color struct {
+				ColorSpace string            `json:"color-space"`
+				Components map[string]string `json:"components"`
+			} `json:"color"`
+		} `json:"colors"`
+	}
+	if err := json.Unmarshal(files[1].Content, &colorSet); err != nil {
+		t.Fatalf("Color set is not valid JSON: %v\n%s", err, files[1].Content)
+	}
+	if len(colorSet.Colors) != 2 {
+		t.Fatalf("Color set has %d colors, want 2", len(colorSet.Colors))
+	}
+
+	light := colorSet.Colors[0]
+	if len(light.Appearances) != 0 || light.Color.Components["red"] != "0xFF" || light.Color.Components["alpha"] != "1.000" {
+		t.Errorf("Light color = %+v", light)
+	}
+	darkColor := colorSet.Colors[1]
+	if len(darkColor.Appearances) != 1 || darkColor.Appearances[0].Value != "dark" ||
+		darkColor.Color.Components["blue"] != "0xFF" || darkColor.Color.Components["alpha"] != "0.500" {
+		t.Errorf("Dark color = %+v", darkColor)
+	}
+}
+
+func TestPlatformNames_Collisions(t *testing.T) {
+	// The default names of rgb(25, 5, 0) and rgb(2, 55, 0)
+	matches := []colors.ColorMatch{
+		{Original: "rgb(25, 5, 0)", Variable: "--color-rgb-25-5-0", Value: "rgb(25, 5, 0)"},
+		{Original: "rgb(2, 55, 0)", Variable: "--color-rgb-2-55-0", Value: "rgb(2, 55, 0)"},
+	}
+
+	files, err := RenderIOSAssetCatalog(matches, nil)
+	if err != nil {
+		t.Fatalf("RenderIOSAssetCatalog() error = %v", err)
+	}
+	if len(files) != 3 || files[1].Path != "rgb25_5_0.colorset/Contents.json" || files[2].Path != "rgb2_55_0.colorset/Contents.json" {
+		t.Errorf("RenderIOSAssetCatalog() files = %+v", files)
+	}
+
+	collision := []colors.ColorMatch{
+		{Variable: "--color-text-primary", Value: "#000000"},
+		{Variable: "--color-text_primary", Value: "#111111"},
+	}
+	if _, err := RenderIOSAssetCatalog(collision, nil); err == nil {
+		t.Error("RenderIOSAssetCatalog() expected error for colliding color set names")
+	}
+	if _, err := RenderAndroidResources(collision, nil); err == nil {
+		t.Error("RenderAndroidResources() expected error for colliding resource names")
+	}
+}
@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+type ReportOptions struct {
+	// Source is the scanned file as shown in the report, e.g. "style.css".
+	Source string
+	// SourceURL links occurrences to the scanned file on a code host, e.g.
+	// "https://github.com/org/repo/blob/main/src/style.css". A "{line}" in
+	// it is replaced by the line number, otherwise "#L<line>" is appended.
+	// Occurrences are shown as plain file:line text when it is empty.
+	SourceURL string
+}
+
+type reportColor struct {
+	Variable    string
+	Value       string
+	Spellings   []string
+	Hex         string
+	RGB         string
+	HSL         string
+	Uses        int
+	OnWhite     reportContrast
+	OnBlack     reportContrast
+	Occurrences []reportOccurrence
+}
+
+type reportContrast struct {
+	Ratio string
+	Level string
+}
+
+type reportOccurrence struct {
+	Location string
+	Property string
+	Href     string
+}
+
+// RenderReport returns a self-contained HTML page showing every variable as
+// a swatch with its formats, usage count, WCAG contrast against white and
+// black, and the list of its occurrences.
+func RenderReport(matches []colors.ColorMatch, opts ReportOptions) ([]byte, error) {
+	var report []reportColor
+	for _, match := range uniqueVariables(matches) {
+		r, g, b, a := colors.ParseToRGBA(match.Value)
+		hex, _ := colors.ConvertColor(match.Value, "hex")
+		rgb, _ := colors.ConvertColor(match.Value, "rgb")
+		if a < 1 {
+			rgb, _ = colors.ConvertColor(match.Value, "rgba")
+		}
+		hsl, _ := colors.ConvertColor(match.Value, "hsl")
+		uses, _ := usage(matches, match.Variable)
+
+		color := reportColor{
+			Variable: match.Variable,
+			Value:    match.Value,
+			Hex:      hex,
+			RGB:      rgb,
+			HSL:      hsl,
+			Uses:     uses,
+			OnWhite:  contrastAgainst(r, g, b, a, 255),
+			OnBlack:  contrastAgainst(r, g, b, a, 0),
+		}
+		for _, other := range matches {
+			if other.Variable != match.Variable {
+				continue
+			}
+			color.Spellings = append(color.Spellings, other.Original)
+			for _, occurrence := range other.Occurrences {
+				color.Occurrences = append(color.Occurrences, reportOccurrence{
+					Location: fmt.Sprintf("%s:%d", opts.Source, occurrence.Line),
+					Property: occurrence.Property,
+					Href:     occurrenceHref(opts.SourceURL, occurrence.Line),
+				})
+			}
+		}
+		report = append(report, color)
+	}
+
+	var buf bytes.Buffer
+	err := reportTemplate.Execute(&buf, struct {
+		Source string
+		Colors []reportColor
+	}{opts.Source, report})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// contrastAgainst returns the contrast of a color, composited over a gray
+// background of the given level, against that background.
+func contrastAgainst(r, g, b uint8, a float64, background uint8) reportContrast {
+	blend := func(c uint8) uint8 {
+		return uint8(math.Round(a*float64(c) + (1-a)*float64(background)))
+	}
+	ratio := colors.ContrastRatio(blend(r), blend(g), blend(b), background, background, background)
+
+	level := "fail"
+	switch {
+	case ratio >= 7:
+		level = "AAA"
+	case ratio >= 4.5:
+		level = "AA"
+	case ratio >= 3:
+		level = "AA large"
+	}
+	return reportContrast{Ratio: fmt.Sprintf("%.2f:1", ratio), Level: level}
+}
+
+func occurrenceHref(sourceURL string, line int) string {
+	if sourceURL == "" {
+		return ""
+	}
+	if strings.Contains(sourceURL, "{line}") {
+		return strings.ReplaceAll(sourceURL, "{line}", strconv.Itoa(line))
+	}
+	return fmt.Sprintf("%s#L%d", sourceURL, line)
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Color palette{{if .Source}} of {{.Source}}{{end}}</title>
+<style>
+  body { font: 14px/1.4 system-ui, sans-serif; margin: 2rem; color: #222; background: #fafafa; }
+  h1 { font-size: 1.4rem; }
+  .colors { display: grid; grid-template-columns: repeat(auto-fill, minmax(260px, 1fr)); gap: 1rem; }
+  .color { background: #fff; border: 1px solid #ddd; border-radius: 8px; overflow: hidden; }
+  .swatch { height: 96px; background-image: linear-gradient(45deg, #ccc 25%, transparent 25%, transparent 75%, #ccc 75%), linear-gradient(45deg, #ccc 25%, transparent 25%, transparent 75%, #ccc 75%); background-size: 16px 16px; background-position: 0 0, 8px 8px; }
+  .swatch div { height: 100%; }
+  .details { padding: 0.75rem; }
+  .variable { font: 600 13px ui-monospace, monospace; }
+  dl { display: grid; grid-template-columns: auto 1fr; gap: 0.2rem 0.75rem; margin: 0.5rem 0; }
+  dt { color: #666; }
+  dd { margin: 0; font-family: ui-monospace, monospace; }
+  .fail { color: #b00020; }
+  ul { margin: 0.25rem 0 0; padding-left: 1.2rem; font-family: ui-monospace, monospace; }
+</style>
+</head>
+<body>
+<h1>Color palette{{if .Source}} of {{.Source}}{{end}}</h1>
+<p>{{len .Colors}} colors</p>
+<div class="colors">
+{{- range .Colors}}
+  <section class="color">
+    <div class="swatch"><div style="background-color: {{.Hex}}"></div></div>
+    <div class="details">
+      <div class="variable">{{.Variable}}</div>
+      <dl>
+        <dt>Hex</dt><dd>{{.Hex}}</dd>
+        <dt>RGB</dt><dd>{{.RGB}}</dd>
+        <dt>HSL</dt><dd>{{.HSL}}</dd>
+        <dt>Source</dt><dd>{{range $i, $s := .Spellings}}{{if $i}}, {{end}}{{$s}}{{end}}</dd>
+        <dt>On white</dt><dd{{if eq .OnWhite.Level "fail"}} class="fail"{{end}}>{{.OnWhite.Ratio}} {{.OnWhite.Level}}</dd>
+        <dt>On black</dt><dd{{if eq .OnBlack.Level "fail"}} class="fail"{{end}}>{{.OnBlack.Ratio}} {{.OnBlack.Level}}</dd>
+      </dl>
+      <details>
+        <summary>{{.Uses}} {{if eq .Uses 1}}use{{else}}uses{{end}}</summary>
+        <ul>
+        {{- range .Occurrences}}
+          <li>{{if .Href}}<a href="{{.Href}}">{{.Location}}</a>{{else}}{{.Location}}{{end}}{{if .Property}} {{.Property}}{{end}}</li>
+        {{- end}}
+        </ul>
+      </details>
+    </div>
+  </section>
+{{- end}}
+</div>
+</body>
+</html>
+`))
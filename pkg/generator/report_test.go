@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"css-color-variable-creator/pkg/colors"
+	"strings"
+	"testing"
+)
+
+func TestRenderReport(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{
+			Original: "#FFF",
+			Variable: "--color-white",
+			Value:    "#FFF",
+			Occurrences: []colors.Occurrence{
+				{Line: 2, Property: "background"},
+				{Line: 5, Property: "color"},
+			},
+		},
+		{
+			Original:    "rgba(0, 0, 0, 0.5)",
+			Variable:    "--color-shadow",
+			Value:       "rgba(0, 0, 0, 0.5)",
+			Occurrences: []colors.Occurrence{{Line: 3, Property: "box-shadow"}},
+		},
+		{
+			Original:    "#fff",
+			Variable:    "--color-white",
+			Value:       "#fff",
+			Occurrences: []colors.Occurrence{{Line: 9, Property: "border-color"}},
+		},
+	}
+
+	content, err := RenderReport(matches, ReportOptions{Source: "style.css", SourceURL: "https://example.com/repo/blob/main/style.css"})
+	if err != nil {
+		t.Fatalf("RenderReport() error = %v", err)
+	}
+	report := string(content)
+
+	for _, want := range []string{
+		"<title>Color palette of style.css</title>",
+		"<p>2 colors</p>",
+		`<div style="background-color: #ffffff">`,
+		`<div style="background-color: #00000080">`,
+		"<dt>HSL</dt><dd>hsla(0, 0%, 0%, 0.50)</dd>",
+		"<dt>Source</dt><dd>#FFF, #fff</dd>",
+		`<dt>On white</dt><dd class="fail">1.00:1 fail</dd>`,
+		"<dt>On black</dt><dd>21.00:1 AAA</dd>",
+		"<summary>3 uses</summary>",
+		"<summary>1 use</summary>",
+		`<li><a href="https://example.com/repo/blob/main/style.css#L9">style.css:9</a> border-color</li>`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("RenderReport() is missing %q", want)
+		}
+	}
+}
+
+func TestOccurrenceHref(t *testing.T) {
+	tests := []struct {
+		sourceURL string
+		want      string
+	}{
+		{"", ""},
+		{"https://github.com/org/repo/blob/main/style.css", "https://github.com/org/repo/blob/main/style.css#L12"},
+		{"https://bitbucket.org/org/repo/src/main/style.css#lines-{line}", "https://bitbucket.org/org/repo/src/main/style.css#lines-12"},
+	}
+
+	for _, tt := range tests {
+		if got := occurrenceHref(tt.sourceURL, 12); got != tt.want {
+			t.Errorf("occurrenceHref(%q) = %q, want %q", tt.sourceURL, got, tt.want)
+		}
+	}
+}
+
+func TestContrastAgainst(t *testing.T) {
+	// Half-transparent black is composited over the background first
+	got := contrastAgainst(0, 0, 0, 0.5, 255)
+	if got.Ratio != "3.95:1" || got.Level != "AA large" {
+		t.Errorf("contrastAgainst() = %+v, want 3.95:1 AA large", got)
+	}
+}
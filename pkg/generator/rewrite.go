@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+// legacyPropertyRegex matches properties whose declarations get a literal
+// fallback copy. Custom properties and Sass variables are left alone.
+var legacyPropertyRegex = regexp.MustCompile(`^-?[a-zA-Z][a-zA-Z0-9-]*$`)
+
+type edit struct {
+	start, end int
+	text       string
+}
+
+// rewriteColors replaces every color in text that has an entry in
+// replacements. With legacy, each declaration containing a replaced color
+// is preceded by a copy of itself that keeps the literal colors:
+//
+//	color: #ff0000;
+//	color: var(--color-ff0000);
+//
+// With scss, "//" line comments are recognized as comments.
+func rewriteColors(text string, replacements map[string]string, legacy, scss bool) string {
+	spans := colors.FindColors(text)
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i][0] < spans[j][0]
+	})
+
+	var edits []edit
+	for _, span := range spans {
+		replacement, ok := replacements[text[span[0]:span[1]]]
+		if !ok {
+			continue
+		}
+		edits = append(edits, edit{span[0], span[1], replacement})
+	}
+
+	if legacy {
+		edits = legacyDeclarations(text, edits, scss)
+	}
+
+	var output strings.Builder
+	last := 0
+	for _, e := range edits {
+		output.WriteString(text[last:e.start])
+		output.WriteString(e.text)
+		last = e.end
+	}
+	output.WriteString(text[last:])
+	return output.String()
+}
+
+// legacyDeclarations merges the color edits of every declaration into one
+// edit that writes the literal declaration followed by the rewritten one.
+// Declaration boundaries inside comments and strings, like the ";" in
+// url("data:image/svg+xml;..."), are ignored.
+func legacyDeclarations(text string, edits []edit, scss bool) []edit {
+	skipped := make([]bool, len(text))
+	commentEnds := make(map[int]bool)
+	for _, span := range colors.SkippedSpans(text, scss) {
+		for i := span[0]; i < span[1]; i++ {
+			skipped[i] = true
+		}
+		if text[span[0]] == '/' {
+			commentEnds[span[1]] = true
+		}
+	}
+
+	var result []edit
+	for i := 0; i < len(edits); {
+		if skipped[edits[i].start] {
+			// A color in a comment or string has no declaration to copy
+			result = append(result, edits[i])
+			i++
+			continue
+		}
+
+		start := declarationStart(text, edits[i].start, skipped, commentEnds)
+		end := len(text)
+		for k := edits[i].end; k < len(text); k++ {
+			if !skipped[k] && (text[k] == ';' || text[k] == '}') {
+				end = k
+				break
+			}
+		}
+
+		// Collect the edits that belong to this declaration
+		j := i + 1
+		for j < len(edits) && edits[j].start < end {
+			j++
+		}
+
+		declaration := text[start:end]
+		property := strings.TrimLeft(declaration, " \t\r\n")
+		propertyStart := start + len(declaration) - len(property)
+		colon := strings.Index(property, ":")
+		if colon < 0 || !legacyPropertyRegex.MatchString(strings.TrimSpace(property[:colon])) {
+			result = append(result, edits[i:j]...)
+			i = j
+			continue
+		}
+
+		var rewritten strings.Builder
+		last := propertyStart
+		for _, e := range edits[i:j] {
+			rewritten.WriteString(text[last:e.start])
+			rewritten.WriteString(e.text)
+			last = e.end
+		}
+		rewritten.WriteString(text[last:end])
+
+		literal := strings.TrimRight(text[propertyStart:end], " \t\r\n")
+		result = append(result, edit{
+			start: propertyStart,
+			end:   end,
+			text:  literal + ";" + declarationSeparator(text, start, propertyStart) + rewritten.String(),
+		})
+		i = j
+	}
+	return result
+}
+
+// declarationStart returns where the declaration containing pos starts:
+// after the previous ";", "{", "}" or comment.
+func declarationStart(text string, pos int, skipped []bool, commentEnds map[int]bool) int {
+	for k := pos - 1; k >= 0; k-- {
+		if commentEnds[k+1] {
+			return k + 1
+		}
+		if skipped[k] {
+			continue
+		}
+		if text[k] == ';' || text[k] == '{' || text[k] == '}' {
+			return k + 1
+		}
+	}
+	return 0
+}
+
+// declarationSeparator returns what goes between the literal and the
+// rewritten declaration: a line break with the same indentation when the
+// declaration starts its own line, a space otherwise.
+func declarationSeparator(text string, start, propertyStart int) string {
+	leading := text[start:propertyStart]
+	newline := strings.LastIndex(leading, "\n")
+	if newline < 0 {
+		lineStart := strings.LastIndex(text[:start], "\n") + 1
+		if strings.TrimSpace(text[lineStart:propertyStart]) != "" {
+			return " "
+		}
+		leading = text[lineStart:propertyStart]
+		newline = -1
+	}
+	return lineEnding(text) + leading[newline+1:]
+}
@@ -0,0 +1,94 @@
+package generator
+
+import "testing"
+
+func TestRewriteColors(t *testing.T) {
+	replacements := map[string]string{
+		"#ff0000":        "var(--color-ff0000)",
+		"rgb(0, 255, 0)": "var(--color-rgb-0-255-0)",
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		legacy bool
+		scss   bool
+		want   string
+	}{
+		{
+			name:  "plain replacement",
+			input: ".a { color: #ff0000; }",
+			want:  ".a { color: var(--color-ff0000); }",
+		},
+		{
+			name:   "legacy declaration on its own line",
+			input:  ".a {\n  color: #ff0000;\n  margin: 0;\n}\n",
+			legacy: true,
+			want:   ".a {\n  color: #ff0000;\n  color: var(--color-ff0000);\n  margin: 0;\n}\n",
+		},
+		{
+			name:   "legacy declaration with crlf",
+			input:  ".a {\r\n\tcolor: #ff0000;\r\n}\r\n",
+			legacy: true,
+			want:   ".a {\r\n\tcolor: #ff0000;\r\n\tcolor: var(--color-ff0000);\r\n}\r\n",
+		},
+		{
+			name:   "legacy inline declarations",
+			input:  ".a { color: #ff0000; background: rgb(0, 255, 0) }",
+			legacy: true,
+			want:   ".a { color: #ff0000; color: var(--color-ff0000); background: rgb(0, 255, 0); background: var(--color-rgb-0-255-0) }",
+		},
+		{
+			name:   "legacy declaration with several colors",
+			input:  "  border: 1px solid #ff0000;\n  box-shadow: 0 0 1px #ff0000, 0 0 2px rgb(0, 255, 0);\n",
+			legacy: true,
+			want:   "  border: 1px solid #ff0000;\n  border: 1px solid var(--color-ff0000);\n  box-shadow: 0 0 1px #ff0000, 0 0 2px rgb(0, 255, 0);\n  box-shadow: 0 0 1px var(--color-ff0000), 0 0 2px var(--color-rgb-0-255-0);\n",
+		},
+		{
+			name:   "legacy declaration with a data uri",
+			input:  ".a {\n  background: url(\"data:image/svg+xml;utf8,<svg/>\") #ff0000;\n}\n",
+			legacy: true,
+			want:   ".a {\n  background: url(\"data:image/svg+xml;utf8,<svg/>\") #ff0000;\n  background: url(\"data:image/svg+xml;utf8,<svg/>\") var(--color-ff0000);\n}\n",
+		},
+		{
+			name:   "legacy declaration after a comment",
+			input:  ".a {\n  /* a; b } */ color: #ff0000;\n}\n",
+			legacy: true,
+			want:   ".a {\n  /* a; b } */ color: #ff0000; color: var(--color-ff0000);\n}\n",
+		},
+		{
+			name:   "legacy leaves colors in comments",
+			input:  ".a {\n  color: red; /* was #ff0000; */\n}\n",
+			legacy: true,
+			want:   ".a {\n  color: red; /* was var(--color-ff0000); */\n}\n",
+		},
+		{
+			name:   "legacy declaration after a scss line comment",
+			input:  ".a {\n  // brand; main\n  color: #ff0000;\n}\n",
+			legacy: true,
+			scss:   true,
+			want:   ".a {\n  // brand; main\n  color: #ff0000;\n  color: var(--color-ff0000);\n}\n",
+		},
+		{
+			name:   "legacy declaration with an unquoted url",
+			input:  ".a {\n  background: url(http://x/a.png) #ff0000; // brand\n}\n",
+			legacy: true,
+			scss:   true,
+			want:   ".a {\n  background: url(http://x/a.png) #ff0000;\n  background: url(http://x/a.png) var(--color-ff0000); // brand\n}\n",
+		},
+		{
+			name:   "legacy skips sass variables and custom properties",
+			input:  "$brand: #ff0000;\n:root { --x: rgb(0, 255, 0); }\n",
+			legacy: true,
+			want:   "$brand: var(--color-ff0000);\n:root { --x: var(--color-rgb-0-255-0); }\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rewriteColors(tt.input, replacements, tt.legacy, tt.scss); got != tt.want {
+				t.Errorf("rewriteColors() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
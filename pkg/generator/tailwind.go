@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+type TailwindOptions struct {
+	// Module is the config file flavor: "cjs" (default) for module.exports,
+	// "esm" for export default, or "ts" for a typed TypeScript module.
+	Module string
+	// Values is what each color maps to: "var" (default) for
+	// var(--color-x) or "literal" for the color itself.
+	Values string
+}
+
+// RenderTailwindConfig returns a tailwind.config file that extends the theme
+// with one color per variable, keyed by its token name so that --color-brand
+// becomes utilities like text-brand.
+func RenderTailwindConfig(matches []colors.ColorMatch, opts TailwindOptions) ([]byte, error) {
+	unique := uniqueVariables(matches)
+	if err := checkKeys(unique, TokenName, "Tailwind color"); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch opts.Module {
+	case "", "cjs":
+		buf.WriteString("/** @type {import('tailwindcss').Config} */\nmodule.exports = {\n")
+	case "esm":
+		buf.WriteString("/** @type {import('tailwindcss').Config} */\nexport default {\n")
+	case "ts":
+		buf.WriteString("import type { Config } from 'tailwindcss'\n\nexport default {\n")
+	default:
+		return nil, fmt.Errorf("unsupported Tailwind config module: %s", opts.Module)
+	}
+
+	buf.WriteString("  theme: {\n    extend: {\n      colors: {\n")
+	for _, match := range unique {
+		var value string
+		switch opts.Values {
+		case "", "var":
+			value = fmt.Sprintf("var(%s)", match.Variable)
+		case "literal":
+			value = match.Value
+		default:
+			return nil, fmt.Errorf("unsupported Tailwind values: %s", opts.Values)
+		}
+		buf.WriteString(fmt.Sprintf("        %s: %s,\n", jsString(TokenName(match.Variable)), jsString(value)))
+	}
+	buf.WriteString("      },\n    },\n  },\n}")
+
+	if opts.Module == "ts" {
+		buf.WriteString(" satisfies Partial<Config>")
+	}
+	buf.WriteString("\n")
+
+	return buf.Bytes(), nil
+}
+
+// jsString quotes s as a single-quoted JavaScript string.
+func jsString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"css-color-variable-creator/pkg/colors"
+	"strings"
+	"testing"
+)
+
+func TestRenderTailwindConfig(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{Original: "#FF0000", Variable: "--color-brand", Value: "#FF0000"},
+		{Original: "rgba(0, 0, 0, 0.5)", Variable: "--shadow", Value: "rgba(0, 0, 0, 0.5)"},
+		{Original: "#ff0000", Variable: "--color-brand", Value: "#ff0000"},
+	}
+
+	tests := []struct {
+		name    string
+		opts    TailwindOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "commonjs vars",
+			opts: TailwindOptions{},
+			want: `/** @type {import('tailwindcss').Config} */
+module.exports = {
+  theme: {
+    extend: {
+      colors: {
+        'brand': 'var(--color-brand)',
+        'shadow': 'var(--shadow)',
+      },
+    },
+  },
+}
+`,
+		},
+		{
+			name: "typescript literals",
+			opts: TailwindOptions{Module: "ts", Values: "literal"},
+			want: `import type { Config } from 'tailwindcss'
+
+export default {
+  theme: {
+    extend: {
+      colors: {
+        'brand': '#FF0000',
+        'shadow': 'rgba(0, 0, 0, 0.5)',
+      },
+    },
+  },
+} satisfies Partial<Config>
+`,
+		},
+		{
+			name:    "unknown values",
+			opts:    TailwindOptions{Values: "hex"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTailwindConfig(matches, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderTailwindConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("RenderTailwindConfig() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+
+	collision := append(matches, colors.ColorMatch{Variable: "--brand", Value: "#00ff00"})
+	_, err := RenderTailwindConfig(collision, TailwindOptions{})
+	if err == nil || !strings.Contains(err.Error(), `--color-brand and --brand both map to the Tailwind color "brand"`) {
+		t.Errorf("RenderTailwindConfig() error = %v, want key collision", err)
+	}
+}
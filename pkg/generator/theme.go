@@ -0,0 +1,177 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+// Themes maps a theme name to the values its variables take in that theme,
+// keyed by variable name (e.g. "dark" -> "--color-ff0000" -> "#1a1a1a").
+type Themes map[string]map[string]string
+
+func LoadThemes(path string) (Themes, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read themes file: %w", err)
+	}
+
+	var themes Themes
+	if err := json.Unmarshal(data, &themes); err != nil {
+		return nil, fmt.Errorf("failed to parse themes file: %w", err)
+	}
+
+	return themes, nil
+}
+
+// ValidateThemes checks that every theme has a usable name and a value for
+// every variable, and that no value could end the declaration it is
+// written into.
+func ValidateThemes(matches []colors.ColorMatch, themes Themes) error {
+	for _, name := range themes.Names() {
+		if name == "" || !utf8.ValidString(name) || strings.IndexFunc(name, unicode.IsControl) >= 0 {
+			return fmt.Errorf("invalid theme name %q", name)
+		}
+		for variable, value := range themes[name] {
+			if !validThemeValue(value) {
+				return fmt.Errorf("theme %q has an invalid value for %s: %q", name, variable, value)
+			}
+		}
+
+		var missing []string
+		for _, match := range uniqueVariables(matches) {
+			if _, ok := themes[name][match.Variable]; !ok {
+				missing = append(missing, match.Variable)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("theme %q is missing values for %s", name, strings.Join(missing, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validThemeValue reports whether value can be written as a declaration
+// value as is. Values that are empty or contain characters that would end
+// the declaration or block, open a comment or string, or break the line are
+// rejected.
+func validThemeValue(value string) bool {
+	if strings.TrimSpace(value) == "" || !utf8.ValidString(value) {
+		return false
+	}
+	if strings.ContainsAny(value, ";{}\"'\\") || strings.Contains(value, "/*") {
+		return false
+	}
+	return strings.IndexFunc(value, unicode.IsControl) < 0
+}
+
+// cssString quotes s as a CSS string, escaping quotes, backslashes and
+// control characters.
+func cssString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, "\\%x ", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// Names returns the theme names in a stable order.
+func (t Themes) Names() []string {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// themeSelector returns the selector of a theme within scope. The theme
+// attribute goes on the host of :host scopes and on a single compound
+// selector itself, e.g. [data-theme="dark"] for :root,
+// :host(.compact[data-theme="dark"]) for :host(.compact) and
+// .app[data-theme="dark"] for .app. Scopes with combinators are themed by an
+// ancestor: [data-theme="dark"] .app .widget for .app .widget.
+func themeSelector(scope, name string) string {
+	attribute := "[data-theme=" + cssString(name) + "]"
+	first, rest := splitCompound(scope)
+	switch {
+	case first == "" || first == ":root":
+		return attribute + rest
+	case first == ":host":
+		return ":host(" + attribute + ")" + rest
+	case isHostFunction(first):
+		return first[:len(first)-1] + attribute + ")" + rest
+	case rest == "":
+		return scope + attribute
+	default:
+		return attribute + " " + scope
+	}
+}
+
+// splitCompound splits selector after its first compound selector, at the
+// first combinator outside brackets, parentheses and strings, e.g. ".app"
+// and " > .widget" for ".app > .widget".
+func splitCompound(selector string) (string, string) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(selector); i++ {
+		c := selector[i]
+		switch {
+		case c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case depth == 0 && strings.IndexByte(" \t\n>+~", c) >= 0:
+			return selector[:i], selector[i:]
+		}
+	}
+	return selector, ""
+}
+
+// isHostFunction reports whether compound is :host() with a selector
+// argument, e.g. :host(.compact), and nothing after it.
+func isHostFunction(compound string) bool {
+	if !strings.HasPrefix(compound, ":host(") {
+		return false
+	}
+	depth := 0
+	for i := len(":host"); i < len(compound); i++ {
+		switch compound[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i == len(compound)-1 && i > len(":host(")
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"css-color-variable-creator/pkg/colors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadThemes(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "theme-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	themesPath := filepath.Join(tempDir, "themes.json")
+	err = os.WriteFile(themesPath, []byte(`{
+  "dark": {"--color-ff0000": "#aa0000"},
+  "contrast": {"--color-ff0000": "#ff0000"}
+}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create themes file: %v", err)
+	}
+
+	themes, err := LoadThemes(themesPath)
+	if err != nil {
+		t.Fatalf("LoadThemes() error = %v", err)
+	}
+
+	if got := strings.Join(themes.Names(), ","); got != "contrast,dark" {
+		t.Errorf("Themes.Names() = %q, want %q", got, "contrast,dark")
+	}
+	if got := themes["dark"]["--color-ff0000"]; got != "#aa0000" {
+		t.Errorf("dark --color-ff0000 = %q, want %q", got, "#aa0000")
+	}
+
+	if _, err := LoadThemes(filepath.Join(tempDir, "missing.json")); err == nil {
+		t.Error("LoadThemes() expected error for missing file")
+	}
+}
+
+func TestValidateThemes(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{Variable: "--color-ff0000", Value: "#ff0000"},
+		{Variable: "--color-00ff00", Value: "#00ff00"},
+	}
+
+	tests := []struct {
+		name        string
+		themes      Themes
+		errContains string
+	}{
+		{
+			name: "complete themes",
+			themes: Themes{
+				"dark": {"--color-ff0000": "#aa0000", "--color-00ff00": "#00aa00"},
+			},
+		},
+		{
+			name:   "no themes",
+			themes: nil,
+		},
+		{
+			name: "missing variable",
+			themes: Themes{
+				"dark": {"--color-ff0000": "#aa0000"},
+			},
+			errContains: `theme "dark" is missing values for --color-00ff00`,
+		},
+		{
+			name: "injected rule",
+			themes: Themes{
+				"dark": {"--color-ff0000": "#000;} body { display: none", "--color-00ff00": "#00aa00"},
+			},
+			errContains: `theme "dark" has an invalid value for --color-ff0000`,
+		},
+		{
+			name: "empty value",
+			themes: Themes{
+				"dark": {"--color-ff0000": " ", "--color-00ff00": "#00aa00"},
+			},
+			errContains: "invalid value",
+		},
+		{
+			name: "control character in name",
+			themes: Themes{
+				"da\nrk": {"--color-ff0000": "#aa0000", "--color-00ff00": "#00aa00"},
+			},
+			errContains: "invalid theme name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateThemes(matches, tt.themes)
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("ValidateThemes() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("ValidateThemes() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestThemeSelector_Escaping(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"dark", `[data-theme="dark"]`},
+		{`a"b`, `[data-theme="a\"b"]`},
+		{`a\b`, `[data-theme="a\\b"]`},
+		{"dunkel-ü", `[data-theme="dunkel-ü"]`},
+	}
+
+	for _, tt := range tests {
+		if got := themeSelector(":root", tt.name); got != tt.want {
+			t.Errorf("themeSelector(%q) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestThemeSelector_Scopes(t *testing.T) {
+	tests := []struct {
+		scope string
+		want  string
+	}{
+		{"", `[data-theme="dark"]`},
+		{":root", `[data-theme="dark"]`},
+		{":host", `:host([data-theme="dark"])`},
+		{":host(.compact)", `:host(.compact[data-theme="dark"])`},
+		{":host(:not(.a))", `:host(:not(.a)[data-theme="dark"])`},
+		{":host(.compact) .inner", `:host(.compact[data-theme="dark"]) .inner`},
+		{":host > .inner", `:host([data-theme="dark"]) > .inner`},
+		{".app", `.app[data-theme="dark"]`},
+		{`.app[title="a b"]`, `.app[title="a b"][data-theme="dark"]`},
+		{".app:not(.a .b)", `.app:not(.a .b)[data-theme="dark"]`},
+		{".app .widget", `[data-theme="dark"] .app .widget`},
+		{".app>.widget", `[data-theme="dark"] .app>.widget`},
+		{":root .app", `[data-theme="dark"] .app`},
+	}
+
+	for _, tt := range tests {
+		if got := themeSelector(tt.scope, "dark"); got != tt.want {
+			t.Errorf("themeSelector(%q) = %s, want %s", tt.scope, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+// usage returns how often a variable's colors occur and the properties they
+// are used in, in file order.
+func usage(matches []colors.ColorMatch, variable string) (int, []string) {
+	count := 0
+	var properties []string
+	seen := make(map[string]bool)
+	for _, match := range matches {
+		if match.Variable != variable {
+			continue
+		}
+		count += len(match.Occurrences)
+		for _, occurrence := range match.Occurrences {
+			if occurrence.Property != "" && !seen[occurrence.Property] {
+				seen[occurrence.Property] = true
+				properties = append(properties, occurrence.Property)
+			}
+		}
+	}
+	return count, properties
+}
+
+// firstLine returns the first line any of a variable's colors occur on.
+func firstLine(matches []colors.ColorMatch, variable string) int {
+	line := math.MaxInt
+	for _, match := range matches {
+		if match.Variable != variable {
+			continue
+		}
+		for _, occurrence := range match.Occurrences {
+			line = min(line, occurrence.Line)
+		}
+		if len(match.Occurrences) == 0 {
+			line = min(line, match.Line)
+		}
+	}
+	return line
+}
+
+// annotations returns the usage comment of every variable for
+// opts.Annotate, or nil when declarations are not annotated.
+func annotations(matches []colors.ColorMatch, opts VariablesOptions) map[string]string {
+	if opts.Annotate == "" || opts.Annotate == "none" {
+		return nil
+	}
+
+	comments := make(map[string]string)
+	for _, match := range uniqueVariables(matches) {
+		count, properties := usage(matches, match.Variable)
+		comment := fmt.Sprintf("%d uses", count)
+		if count == 1 {
+			comment = "1 use"
+		}
+		if opts.Annotate == "full" {
+			if len(properties) > 0 {
+				comment += ": " + strings.Join(properties, ", ")
+			}
+			location := fmt.Sprintf("line %d", firstLine(matches, match.Variable))
+			if opts.Source != "" {
+				location = fmt.Sprintf("%s:%d", opts.Source, firstLine(matches, match.Variable))
+			}
+			comment += "; " + location
+		}
+		comments[match.Variable] = comment
+	}
+	return comments
+}
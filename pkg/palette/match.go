@@ -0,0 +1,60 @@
+package palette
+
+import (
+	"fmt"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+// Apply names every opaque color that equals a swatch after that swatch.
+// With a threshold above 0, colors without an equal swatch are snapped to
+// the closest swatch within that CIEDE2000 ΔE and take its color. Other
+// colors keep their names. It returns how many colors were named and a
+// note per snapped color.
+func Apply(matches []colors.ColorMatch, swatches []Swatch, threshold float64) (int, []string) {
+	named := 0
+	var notes []string
+	for i := range matches {
+		r, g, b, a := colors.ParseToRGBA(matches[i].Original)
+		if a < 1 {
+			continue
+		}
+
+		swatch, distance, ok := nearestSwatch(r, g, b, swatches)
+		if !ok || distance > threshold {
+			continue
+		}
+
+		matches[i].Variable = VariableName(swatch.Name)
+		if distance > 0 {
+			matches[i].Value = swatch.Hex()
+			notes = append(notes, fmt.Sprintf("snapped %s to swatch %q (%s, ΔE %.2f)",
+				matches[i].Original, swatch.Name, swatch.Hex(), distance))
+		}
+		named++
+	}
+	return named, notes
+}
+
+// VariableName returns the custom property for a swatch, e.g.
+// "--color-brand-red" for "Brand Red".
+func VariableName(name string) string {
+	return colors.SanitizeName("--color-" + strings.ToLower(name))
+}
+
+// nearestSwatch returns the first swatch closest to the color.
+func nearestSwatch(r, g, b uint8, swatches []Swatch) (Swatch, float64, bool) {
+	var best Swatch
+	bestDistance := 0.0
+	for i, swatch := range swatches {
+		distance := 0.0
+		if swatch.R != r || swatch.G != g || swatch.B != b {
+			distance = colors.DeltaE(r, g, b, swatch.R, swatch.G, swatch.B)
+		}
+		if i == 0 || distance < bestDistance {
+			best, bestDistance = swatch, distance
+		}
+	}
+	return best, bestDistance, len(swatches) > 0
+}
@@ -0,0 +1,46 @@
+package palette
+
+import (
+	"testing"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+func TestApply(t *testing.T) {
+	swatches := []Swatch{
+		{Name: "Brand Red", R: 255, G: 0, B: 0},
+		{Name: "Sky", R: 0, G: 128, B: 255},
+	}
+	newMatches := func() []colors.ColorMatch {
+		return []colors.ColorMatch{
+			{Original: "#ff0000", Variable: "--color-ff0000", Value: "#ff0000"},
+			{Original: "#fe0101", Variable: "--color-fe0101", Value: "#fe0101"},
+			{Original: "rgba(255, 0, 0, 0.5)", Variable: "--color-rgba", Value: "rgba(255, 0, 0, 0.5)"},
+			{Original: "#00ff00", Variable: "--color-00ff00", Value: "#00ff00"},
+		}
+	}
+
+	matches := newMatches()
+	named, notes := Apply(matches, swatches, 0)
+	if named != 1 || len(notes) != 0 {
+		t.Errorf("Apply() without threshold = %d, %v, want 1 exact match and no notes", named, notes)
+	}
+	want := []string{"--color-brand-red", "--color-fe0101", "--color-rgba", "--color-00ff00"}
+	for i := range want {
+		if matches[i].Variable != want[i] {
+			t.Errorf("%s Variable = %q, want %q", matches[i].Original, matches[i].Variable, want[i])
+		}
+	}
+
+	matches = newMatches()
+	named, notes = Apply(matches, swatches, 2)
+	if named != 2 || len(notes) != 1 {
+		t.Errorf("Apply() with threshold = %d, %v, want 2 matches and a note for #fe0101", named, notes)
+	}
+	if matches[1].Variable != "--color-brand-red" || matches[1].Value != "#ff0000" {
+		t.Errorf("Snapped match = %+v, want --color-brand-red with #ff0000", matches[1])
+	}
+	if matches[3].Variable != "--color-00ff00" {
+		t.Errorf("Distant match Variable = %q, want unchanged", matches[3].Variable)
+	}
+}
@@ -0,0 +1,210 @@
+package palette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Swatch is a named color from a designer's palette.
+type Swatch struct {
+	Name    string
+	R, G, B uint8
+}
+
+// Hex returns the swatch color as "#rrggbb".
+func (s Swatch) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", s.R, s.G, s.B)
+}
+
+// Palette is the named swatches of a palette file, in file order.
+type Palette struct {
+	Swatches []Swatch
+	// Skipped lists swatches that could not be read, such as unnamed ones
+	// or ones in an unsupported color model.
+	Skipped []string
+}
+
+// Load reads a GIMP/Inkscape .gpl or Adobe Swatch Exchange .ase palette.
+func Load(path string) (*Palette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read palette file: %w", err)
+	}
+
+	var palette *Palette
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gpl":
+		palette, err = parseGPL(data)
+	case ".ase":
+		palette, err = parseASE(data)
+	default:
+		return nil, fmt.Errorf("unsupported palette file %s. Must end in .gpl or .ase", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse palette file %s: %w", path, err)
+	}
+	return palette, nil
+}
+
+func parseGPL(data []byte) (*Palette, error) {
+	palette := &Palette{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if lineNum == 1 {
+			if line != "GIMP Palette" {
+				return nil, fmt.Errorf("missing \"GIMP Palette\" header")
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "Name:") || strings.HasPrefix(line, "Columns:") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("line %d: expected \"R G B name\"", lineNum)
+		}
+		var rgb [3]uint8
+		for i := range rgb {
+			value, err := strconv.ParseUint(fields[i], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid color component %q", lineNum, fields[i])
+			}
+			rgb[i] = uint8(value)
+		}
+
+		name := strings.Join(fields[3:], " ")
+		if name == "" || name == "Untitled" {
+			palette.Skipped = append(palette.Skipped, fmt.Sprintf("line %d: unnamed swatch", lineNum))
+			continue
+		}
+		palette.Swatches = append(palette.Swatches, Swatch{Name: name, R: rgb[0], G: rgb[1], B: rgb[2]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return palette, nil
+}
+
+// ASE block types and the color type of a normal (not global or spot)
+// swatch, shared with the ASE writer.
+const (
+	ASEColorEntry  = 0x0001
+	ASENormalColor = 2
+	aseGroupStart  = 0xC001
+	aseGroupEnd    = 0xC002
+)
+
+func parseASE(data []byte) (*Palette, error) {
+	if len(data) < 12 || string(data[:4]) != "ASEF" {
+		return nil, fmt.Errorf("missing ASEF signature")
+	}
+	count := binary.BigEndian.Uint32(data[8:12])
+
+	palette := &Palette{}
+	pos := 12
+	for block := uint32(0); block < count; block++ {
+		if pos+6 > len(data) {
+			return nil, fmt.Errorf("block %d: unexpected end of file", block+1)
+		}
+		blockType := binary.BigEndian.Uint16(data[pos : pos+2])
+		length := int(binary.BigEndian.Uint32(data[pos+2 : pos+6]))
+		pos += 6
+		if pos+length > len(data) {
+			return nil, fmt.Errorf("block %d: unexpected end of file", block+1)
+		}
+		body := data[pos : pos+length]
+		pos += length
+
+		switch blockType {
+		case aseGroupStart, aseGroupEnd:
+			continue
+		case ASEColorEntry:
+		default:
+			return nil, fmt.Errorf("block %d: unknown block type %#04x", block+1, blockType)
+		}
+
+		swatch, err := parseASEColor(body)
+		if err != nil {
+			palette.Skipped = append(palette.Skipped, fmt.Sprintf("block %d: %v", block+1, err))
+			continue
+		}
+		palette.Swatches = append(palette.Swatches, swatch)
+	}
+	return palette, nil
+}
+
+func parseASEColor(body []byte) (Swatch, error) {
+	if len(body) < 2 {
+		return Swatch{}, fmt.Errorf("truncated color entry")
+	}
+	nameLength := int(binary.BigEndian.Uint16(body[:2]))
+	if len(body) < 2+nameLength*2+4 {
+		return Swatch{}, fmt.Errorf("truncated color entry")
+	}
+	units := make([]uint16, nameLength)
+	for i := range units {
+		units[i] = binary.BigEndian.Uint16(body[2+i*2:])
+	}
+	name := strings.TrimRight(string(utf16.Decode(units)), "\x00")
+	rest := body[2+nameLength*2:]
+	model := string(rest[:4])
+	rest = rest[4:]
+
+	components := func(n int) ([]float64, error) {
+		if len(rest) < n*4 {
+			return nil, fmt.Errorf("truncated %s color %q", strings.TrimSpace(model), name)
+		}
+		values := make([]float64, n)
+		for i := range values {
+			values[i] = float64(math.Float32frombits(binary.BigEndian.Uint32(rest[i*4:])))
+		}
+		return values, nil
+	}
+
+	var r, g, b float64
+	switch model {
+	case "RGB ":
+		values, err := components(3)
+		if err != nil {
+			return Swatch{}, err
+		}
+		r, g, b = values[0], values[1], values[2]
+	case "Gray":
+		values, err := components(1)
+		if err != nil {
+			return Swatch{}, err
+		}
+		r, g, b = values[0], values[0], values[0]
+	case "CMYK":
+		values, err := components(4)
+		if err != nil {
+			return Swatch{}, err
+		}
+		k := 1 - values[3]
+		r, g, b = (1-values[0])*k, (1-values[1])*k, (1-values[2])*k
+	default:
+		return Swatch{}, fmt.Errorf("swatch %q uses the unsupported %s color model", name, strings.TrimSpace(model))
+	}
+
+	if name == "" {
+		return Swatch{}, fmt.Errorf("unnamed swatch")
+	}
+	return Swatch{Name: name, R: unit(r), G: unit(g), B: unit(b)}, nil
+}
+
+// unit converts a 0–1 color component to 0–255.
+func unit(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
@@ -0,0 +1,125 @@
+package palette
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestLoad_GPL(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "palette-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "brand.gpl")
+	content := `GIMP Palette
+Name: Brand
+Columns: 4
+# exported from Inkscape
+255   0   0	Brand Red
+  0 128 255	Sky
+ 10  10  10	Untitled
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write palette file: %v", err)
+	}
+
+	palette, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := []Swatch{
+		{Name: "Brand Red", R: 255, G: 0, B: 0},
+		{Name: "Sky", R: 0, G: 128, B: 255},
+	}
+	if !reflect.DeepEqual(palette.Swatches, want) {
+		t.Errorf("Load() swatches = %+v, want %+v", palette.Swatches, want)
+	}
+	if len(palette.Skipped) != 1 {
+		t.Errorf("Load() skipped = %v, want the untitled swatch", palette.Skipped)
+	}
+
+	if err := os.WriteFile(path, []byte("Brand\n255 0 0 Red\n"), 0644); err != nil {
+		t.Fatalf("Failed to write palette file: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() without GIMP Palette header expected error")
+	}
+}
+
+func TestLoad_ASE(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "palette-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	var data bytes.Buffer
+	data.WriteString("ASEF")
+	binary.Write(&data, binary.BigEndian, []uint16{1, 0})
+	binary.Write(&data, binary.BigEndian, uint32(6))
+	writeASEBlock(&data, aseGroupStart, aseName("Brand"))
+	writeASEBlock(&data, ASEColorEntry, aseColor("Brand Red", "RGB ", 1, 0, 0))
+	writeASEBlock(&data, ASEColorEntry, aseColor("Mid Gray", "Gray", 0.5))
+	writeASEBlock(&data, ASEColorEntry, aseColor("Ink", "CMYK", 0, 0, 0, 1))
+	writeASEBlock(&data, ASEColorEntry, aseColor("Lab Teal", "LAB ", 0.5, -40, -10))
+	writeASEBlock(&data, aseGroupEnd, nil)
+
+	path := filepath.Join(tempDir, "brand.ase")
+	if err := os.WriteFile(path, data.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write palette file: %v", err)
+	}
+
+	palette, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := []Swatch{
+		{Name: "Brand Red", R: 255, G: 0, B: 0},
+		{Name: "Mid Gray", R: 128, G: 128, B: 128},
+		{Name: "Ink", R: 0, G: 0, B: 0},
+	}
+	if !reflect.DeepEqual(palette.Swatches, want) {
+		t.Errorf("Load() swatches = %+v, want %+v", palette.Swatches, want)
+	}
+	if len(palette.Skipped) != 1 {
+		t.Errorf("Load() skipped = %v, want the LAB swatch", palette.Skipped)
+	}
+
+	if err := os.WriteFile(path, data.Bytes()[:40], 0644); err != nil {
+		t.Fatalf("Failed to write palette file: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() of a truncated file expected error")
+	}
+}
+
+func writeASEBlock(buf *bytes.Buffer, blockType uint16, body []byte) {
+	binary.Write(buf, binary.BigEndian, blockType)
+	binary.Write(buf, binary.BigEndian, uint32(len(body)))
+	buf.Write(body)
+}
+
+func aseName(name string) []byte {
+	var buf bytes.Buffer
+	units := utf16.Encode([]rune(name + "\x00"))
+	binary.Write(&buf, binary.BigEndian, uint16(len(units)))
+	binary.Write(&buf, binary.BigEndian, units)
+	return buf.Bytes()
+}
+
+func aseColor(name, model string, components ...float32) []byte {
+	buf := bytes.NewBuffer(aseName(name))
+	buf.WriteString(model)
+	binary.Write(buf, binary.BigEndian, components)
+	binary.Write(buf, binary.BigEndian, uint16(2))
+	return buf.Bytes()
+}
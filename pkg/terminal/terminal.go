@@ -0,0 +1,137 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+// IsTerminal reports whether f is attached to a terminal.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ColorEnabled reports whether colored output should be written to f. It
+// honors NO_COLOR (https://no-color.org) and TERM=dumb.
+func ColorEnabled(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal(f)
+}
+
+// ColorLevel is how many colors a terminal can show.
+type ColorLevel int
+
+const (
+	// NoColor means plain text only.
+	NoColor ColorLevel = iota
+	// Color256 is the xterm 256-color palette.
+	Color256
+	// TrueColor is 24-bit RGB.
+	TrueColor
+)
+
+// ColorSupport returns the color level of f. Truecolor is detected from
+// COLORTERM and 256 colors from TERM; anything else, and output that
+// ColorEnabled rules out, is plain text.
+func ColorSupport(f *os.File) ColorLevel {
+	if !ColorEnabled(f) {
+		return NoColor
+	}
+	return colorLevel(os.Getenv("COLORTERM"), os.Getenv("TERM"))
+}
+
+func colorLevel(colorterm, term string) ColorLevel {
+	switch {
+	case colorterm == "truecolor" || colorterm == "24bit":
+		return TrueColor
+	case strings.Contains(term, "256color"):
+		return Color256
+	default:
+		return NoColor
+	}
+}
+
+// Swatch returns a two-cell block in the given color, or "" for NoColor.
+func Swatch(level ColorLevel, r, g, b uint8) string {
+	switch level {
+	case TrueColor:
+		return fmt.Sprintf("\x1b[48;2;%d;%d;%dm  \x1b[0m", r, g, b)
+	case Color256:
+		return fmt.Sprintf("\x1b[48;5;%dm  \x1b[0m", xterm256(r, g, b))
+	default:
+		return ""
+	}
+}
+
+// cubeLevels are the channel values of the xterm 6x6x6 color cube.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// xterm256 returns the closest xterm 256-color index, from the color cube
+// (16–231) or the gray ramp (232–255).
+func xterm256(r, g, b uint8) int {
+	nearestLevel := func(c uint8) int {
+		best := 0
+		for i, level := range cubeLevels {
+			if abs(int(c)-level) < abs(int(c)-cubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	ri, gi, bi := nearestLevel(r), nearestLevel(g), nearestLevel(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDistance := distance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	gray := (int(r) + int(g) + int(b)) / 3
+	step := min(max((gray-8+5)/10, 0), 23)
+	grayLevel := 8 + 10*step
+	if distance(r, g, b, grayLevel, grayLevel, grayLevel) < cubeDistance {
+		return 232 + step
+	}
+	return cube
+}
+
+func distance(r, g, b uint8, r2, g2, b2 int) int {
+	dr, dg, db := int(r)-r2, int(g)-g2, int(b)-b2
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// PrintSwatches writes one line per variable: a swatch for level, the
+// variable name and its value.
+func PrintSwatches(w io.Writer, matches []colors.ColorMatch, level ColorLevel) {
+	width := 0
+	for _, match := range matches {
+		width = max(width, len(match.Variable))
+	}
+
+	seen := make(map[string]bool)
+	for _, match := range matches {
+		if seen[match.Variable] {
+			continue
+		}
+		seen[match.Variable] = true
+
+		prefix := "  "
+		if level != NoColor {
+			r, g, b, _ := colors.ParseToRGBA(match.Value)
+			prefix += Swatch(level, r, g, b) + " "
+		}
+		fmt.Fprintf(w, "%s%-*s  %s\n", prefix, width, match.Variable, match.Value)
+	}
+}
@@ -0,0 +1,107 @@
+package terminal
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+func TestColorEnabled(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	if IsTerminal(w) {
+		t.Error("IsTerminal() = true for a pipe")
+	}
+	if ColorEnabled(w) {
+		t.Error("ColorEnabled() = true for a pipe")
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		t.Skip("no terminal available")
+	}
+	defer tty.Close()
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+	if !ColorEnabled(tty) {
+		t.Error("ColorEnabled() = false for a terminal")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if ColorEnabled(tty) {
+		t.Error("ColorEnabled() = true with NO_COLOR set")
+	}
+}
+
+func TestColorLevel(t *testing.T) {
+	tests := []struct {
+		colorterm string
+		term      string
+		want      ColorLevel
+	}{
+		{"truecolor", "xterm-256color", TrueColor},
+		{"24bit", "xterm", TrueColor},
+		{"", "xterm-256color", Color256},
+		{"", "screen-256color", Color256},
+		{"", "xterm", NoColor},
+		{"", "", NoColor},
+	}
+
+	for _, tt := range tests {
+		if got := colorLevel(tt.colorterm, tt.term); got != tt.want {
+			t.Errorf("colorLevel(%q, %q) = %v, want %v", tt.colorterm, tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestSwatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   ColorLevel
+		r, g, b uint8
+		want    string
+	}{
+		{"truecolor", TrueColor, 255, 128, 0, "\x1b[48;2;255;128;0m  \x1b[0m"},
+		{"256 cube", Color256, 255, 0, 0, "\x1b[48;5;196m  \x1b[0m"},
+		{"256 gray", Color256, 128, 128, 128, "\x1b[48;5;244m  \x1b[0m"},
+		{"plain", NoColor, 255, 0, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Swatch(tt.level, tt.r, tt.g, tt.b); got != tt.want {
+				t.Errorf("Swatch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintSwatches(t *testing.T) {
+	matches := []colors.ColorMatch{
+		{Variable: "--color-red", Value: "#ff0000"},
+		{Variable: "--color-text", Value: "rgb(0, 0, 0)"},
+		{Variable: "--color-red", Value: "#f00"},
+	}
+
+	var buf bytes.Buffer
+	PrintSwatches(&buf, matches, NoColor)
+	want := "  --color-red   #ff0000\n  --color-text  rgb(0, 0, 0)\n"
+	if buf.String() != want {
+		t.Errorf("PrintSwatches() = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	PrintSwatches(&buf, matches[:1], TrueColor)
+	want = "  \x1b[48;2;255;0;0m  \x1b[0m --color-red  #ff0000\n"
+	if buf.String() != want {
+		t.Errorf("PrintSwatches() = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,56 @@
+package tokens
+
+import (
+	"fmt"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+// Match points every scanned color at the token with the same color or, when
+// none has it, the closest token within tolerance (CIEDE2000 ΔE) that has
+// the same alpha. Matched colors take the token's variable and value.
+// Colors without a matching token are returned in unmatched and left out of
+// matched, so they stay as they are in the rewritten file. notes lists the
+// colors matched within tolerance.
+func Match(matches []colors.ColorMatch, tokens []Token, tolerance float64) (matched, unmatched []colors.ColorMatch, notes []string) {
+	for _, match := range matches {
+		token, distance, ok := nearestToken(match.Original, tokens)
+		if !ok || distance > tolerance {
+			unmatched = append(unmatched, match)
+			continue
+		}
+
+		if distance > 0 {
+			notes = append(notes, fmt.Sprintf("matched %s to token %s (%s, ΔE %.2f)",
+				match.Original, token.Path, token.Value, distance))
+		}
+		match.Variable = token.Variable
+		match.Value = token.Value
+		matched = append(matched, match)
+	}
+	return matched, unmatched, notes
+}
+
+// nearestToken returns the first token closest to color among those with
+// the same alpha.
+func nearestToken(color string, tokens []Token) (Token, float64, bool) {
+	r, g, b, a := colors.ParseToRGBA(color)
+
+	var best Token
+	bestDistance := 0.0
+	found := false
+	for _, token := range tokens {
+		tr, tg, tb, ta := colors.ParseToRGBA(token.Value)
+		if ta != a {
+			continue
+		}
+		distance := 0.0
+		if tr != r || tg != g || tb != b {
+			distance = colors.DeltaE(r, g, b, tr, tg, tb)
+		}
+		if !found || distance < bestDistance {
+			best, bestDistance, found = token, distance, true
+		}
+	}
+	return best, bestDistance, found
+}
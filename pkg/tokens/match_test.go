@@ -0,0 +1,53 @@
+package tokens
+
+import (
+	"testing"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+func TestMatch(t *testing.T) {
+	tokens := []Token{
+		{Path: "color.brand", Variable: "--color-brand", Value: "#ff0000"},
+		{Path: "color.danger", Variable: "--color-danger", Value: "#ff0000"},
+		{Path: "color.overlay", Variable: "--color-overlay", Value: "rgba(0, 0, 0, 0.5)"},
+	}
+	matches := []colors.ColorMatch{
+		{Original: "#F00", Variable: "--color-f00", Value: "#F00"},
+		{Original: "#fe0101", Variable: "--color-fe0101", Value: "#fe0101"},
+		{Original: "#00000080", Variable: "--color-00000080", Value: "#00000080"},
+		{Original: "#000000", Variable: "--color-000000", Value: "#000000"},
+		{Original: "#0000ff", Variable: "--color-0000ff", Value: "#0000ff"},
+	}
+
+	matched, unmatched, notes := Match(matches, tokens, 2)
+
+	wantMatched := map[string]string{
+		"#F00":      "--color-brand",
+		"#fe0101":   "--color-brand",
+		"#00000080": "--color-overlay",
+	}
+	if len(matched) != len(wantMatched) {
+		t.Fatalf("Match() matched %d colors, want %d", len(matched), len(wantMatched))
+	}
+	for _, match := range matched {
+		if match.Variable != wantMatched[match.Original] {
+			t.Errorf("Match() %s variable = %s, want %s", match.Original, match.Variable, wantMatched[match.Original])
+		}
+	}
+	if matched[1].Value != "#ff0000" {
+		t.Errorf("Match() %s value = %s, want the token value #ff0000", matched[1].Original, matched[1].Value)
+	}
+
+	if len(unmatched) != 2 || unmatched[0].Original != "#000000" || unmatched[1].Original != "#0000ff" {
+		t.Errorf("Match() unmatched = %+v, want #000000 and #0000ff", unmatched)
+	}
+	if len(notes) != 1 {
+		t.Errorf("Match() notes = %v, want one note for #fe0101", notes)
+	}
+
+	_, unmatched, _ = Match(matches[:2], tokens, 0)
+	if len(unmatched) != 1 || unmatched[0].Original != "#fe0101" {
+		t.Errorf("Match() with tolerance 0 unmatched = %+v, want #fe0101", unmatched)
+	}
+}
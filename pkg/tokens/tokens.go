@@ -0,0 +1,261 @@
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+
+	"css-color-variable-creator/pkg/colors"
+)
+
+// Token is a color from a design tokens file.
+type Token struct {
+	// Path is the dotted token path, e.g. "color.brand.primary".
+	Path string
+	// Variable is the custom property derived from the path, e.g.
+	// "--color-brand-primary".
+	Variable string
+	// Value is the color as written in the tokens file, with aliases
+	// resolved.
+	Value string
+}
+
+var (
+	aliasRegex     = regexp.MustCompile(`^\{([^{}]+)\}$`)
+	camelCaseRegex = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+)
+
+// maxAliasDepth limits how many aliases are followed to resolve a value, so
+// reference cycles fail instead of looping.
+const maxAliasDepth = 10
+
+// Load reads the color tokens from a Design Tokens Community Group or Tokens
+// Studio JSON file, in file order. Tokens are objects with a "$value" (DTCG)
+// or "value" (Tokens Studio) key; a "$type" or "type" set on a token or one
+// of its groups must be "color". Aliases like "{color.red}" are resolved.
+// In Tokens Studio files with several token sets the set name starts the
+// token path, so "color.primary" in the "dark" set is "dark.color.primary".
+// Color tokens whose value is not a supported color are skipped with a note.
+func Load(path string) ([]Token, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read tokens file: %w", err)
+	}
+
+	loader := tokenLoader{byPath: make(map[string]*loadedToken), byLocal: make(map[string][]*loadedToken)}
+	if err := loader.walk(data, "", nil, ""); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse tokens file %s: %w", path, err)
+	}
+
+	var tokens []Token
+	var notes []string
+	owners := make(map[string]Token)
+	for _, token := range loader.tokens {
+		value, err := loader.resolve(token)
+		if err != nil {
+			return nil, nil, fmt.Errorf("token %s: %w", token.Path, err)
+		}
+		if !colors.IsColor(value) {
+			if token.typed {
+				notes = append(notes, fmt.Sprintf("skipped token %s: unsupported color value %q", token.Path, value))
+			}
+			continue
+		}
+
+		// Two paths can give one variable, e.g. color.brandRed and
+		// color.brand-red; they must not disagree on the color
+		if owner, ok := owners[token.Variable]; ok {
+			if !sameColor(owner.Value, value) {
+				return nil, nil, fmt.Errorf("tokens %s and %s both map to %s with different values %s and %s", owner.Path, token.Path, token.Variable, owner.Value, value)
+			}
+		} else {
+			owners[token.Variable] = Token{Path: token.Path, Value: value}
+		}
+
+		token.Value = value
+		tokens = append(tokens, token.Token)
+	}
+	return tokens, notes, nil
+}
+
+// loadedToken is a token as read, before aliases are resolved.
+type loadedToken struct {
+	Token
+	// set is the token set in multi-set files, and local the dotted path
+	// within it, which is what aliases refer to.
+	set, local string
+	// typed records tokens declared as colors.
+	typed bool
+}
+
+type tokenLoader struct {
+	tokens  []*loadedToken
+	byPath  map[string]*loadedToken
+	byLocal map[string][]*loadedToken
+}
+
+// walk reads the JSON object in data. Keys are visited in file order so
+// earlier tokens win ties when matching.
+func (l *tokenLoader) walk(data []byte, set string, path []string, inheritedType string) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil {
+		return err
+	} else if token != json.Delim('{') {
+		return fmt.Errorf("expected a JSON object")
+	}
+
+	var keys []string
+	fields := make(map[string]json.RawMessage)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key := token.(string)
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return fmt.Errorf("value for %s: %w", key, err)
+		}
+		keys = append(keys, key)
+		fields[key] = raw
+	}
+
+	tokenType := inheritedType
+	for _, key := range []string{"$type", "type"} {
+		if raw, ok := fields[key]; ok {
+			json.Unmarshal(raw, &tokenType)
+		}
+	}
+
+	for _, key := range []string{"$value", "value"} {
+		raw, ok := fields[key]
+		if !ok || (key == "value" && fields["type"] == nil && inheritedType == "") {
+			continue
+		}
+		if tokenType != "" && tokenType != "color" {
+			return nil
+		}
+		l.add(set, path, raw, tokenType == "color")
+		return nil
+	}
+
+	// Tokens Studio files with several token sets name them at the top
+	// level. Sets often define the same paths with different values, like
+	// a light and a dark set, so the set name is kept apart from the path.
+	_, hasMetadata := fields["$metadata"]
+	_, hasThemes := fields["$themes"]
+	multiSet := set == "" && len(path) == 0 && (hasMetadata || hasThemes)
+
+	for _, key := range keys {
+		raw := fields[key]
+		if strings.HasPrefix(key, "$") || len(raw) == 0 || raw[0] != '{' {
+			continue
+		}
+		var err error
+		if multiSet {
+			err = l.walk(raw, key, nil, tokenType)
+		} else {
+			err = l.walk(raw, set, append(append([]string(nil), path...), key), tokenType)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *tokenLoader) add(set string, path []string, raw json.RawMessage, typed bool) {
+	var value string
+	if json.Unmarshal(raw, &value) != nil {
+		// DTCG color objects carry an optional hex fallback
+		var object struct {
+			Hex   string   `json:"hex"`
+			Alpha *float64 `json:"alpha"`
+		}
+		json.Unmarshal(raw, &object)
+		value = object.Hex
+		if value != "" && object.Alpha != nil && *object.Alpha < 1 {
+			value += fmt.Sprintf("%02x", uint8(math.Round(*object.Alpha*255)))
+		}
+	}
+
+	full := path
+	if set != "" {
+		full = append([]string{set}, path...)
+	}
+	token := &loadedToken{
+		Token: Token{
+			Path:     strings.Join(full, "."),
+			Variable: VariableName(full),
+			Value:    strings.TrimSpace(value),
+		},
+		set:   set,
+		local: strings.Join(path, "."),
+		typed: typed,
+	}
+	l.tokens = append(l.tokens, token)
+	l.byPath[token.Path] = token
+	l.byLocal[token.local] = append(l.byLocal[token.local], token)
+}
+
+// VariableName returns the custom property for a token path in kebab case,
+// e.g. "--color-brand-primary" for color.brand.primary and color.brandPrimary.
+func VariableName(path []string) string {
+	name := camelCaseRegex.ReplaceAllString(strings.Join(path, "-"), "$1-$2")
+	return colors.SanitizeName("--" + strings.ToLower(name))
+}
+
+// resolve follows the aliases of token to a value.
+func (l *tokenLoader) resolve(token *loadedToken) (string, error) {
+	value, set := token.Value, token.set
+	for depth := 0; depth < maxAliasDepth; depth++ {
+		parts := aliasRegex.FindStringSubmatch(value)
+		if parts == nil {
+			return value, nil
+		}
+		target, err := l.lookup(parts[1], set)
+		if err != nil {
+			return "", err
+		}
+		value, set = target.Value, target.set
+	}
+	return "", fmt.Errorf("alias %s nests too deeply", value)
+}
+
+// lookup finds the token an alias refers to. In multi-set files aliases
+// leave out the set name: the token's own set is tried first, then a path
+// defined by exactly one other set.
+func (l *tokenLoader) lookup(ref, set string) (*loadedToken, error) {
+	if set != "" {
+		if target, ok := l.byPath[set+"."+ref]; ok {
+			return target, nil
+		}
+	}
+	if target, ok := l.byPath[ref]; ok {
+		return target, nil
+	}
+
+	candidates := l.byLocal[ref]
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("unknown alias {%s}", ref)
+	case 1:
+		return candidates[0], nil
+	default:
+		var sets []string
+		for _, candidate := range candidates {
+			sets = append(sets, candidate.set)
+		}
+		return nil, fmt.Errorf("ambiguous alias {%s}: defined in sets %s", ref, strings.Join(sets, ", "))
+	}
+}
+
+func sameColor(a, b string) bool {
+	r1, g1, b1, a1 := colors.ParseToRGBA(a)
+	r2, g2, b2, a2 := colors.ParseToRGBA(b)
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+}
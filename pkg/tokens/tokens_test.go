@@ -0,0 +1,173 @@
+package tokens
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "tokens-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name    string
+		content string
+		want    []Token
+		notes   []string
+		wantErr bool
+	}{
+		{
+			name: "dtcg",
+			content: `{
+  "color": {
+    "$type": "color",
+    "brand": {
+      "primary": { "$value": "#FF0000" },
+      "overlay": { "$value": "rgba(0, 0, 255, 0.5)" }
+    },
+    "danger": { "$value": "{color.brand.primary}" },
+    "surface": { "$value": { "colorSpace": "srgb", "components": [1, 1, 1], "hex": "#ffffff" } }
+  },
+  "spacing": {
+    "small": { "$type": "dimension", "$value": "4px" }
+  }
+}`,
+			want: []Token{
+				{Path: "color.brand.primary", Variable: "--color-brand-primary", Value: "#FF0000"},
+				{Path: "color.brand.overlay", Variable: "--color-brand-overlay", Value: "rgba(0, 0, 255, 0.5)"},
+				{Path: "color.danger", Variable: "--color-danger", Value: "#FF0000"},
+				{Path: "color.surface", Variable: "--color-surface", Value: "#ffffff"},
+			},
+		},
+		{
+			name: "tokens studio",
+			content: `{
+  "global": {
+    "textPrimary": { "value": "#111111", "type": "color" },
+    "radius": { "value": "4", "type": "borderRadius" }
+  },
+  "$themes": [],
+  "$metadata": { "tokenSetOrder": ["global"] }
+}`,
+			want: []Token{
+				{Path: "global.textPrimary", Variable: "--global-text-primary", Value: "#111111"},
+			},
+		},
+		{
+			name: "tokens studio sets",
+			content: `{
+  "core": {
+    "blue": { "value": "#0000ff", "type": "color" }
+  },
+  "light": {
+    "color": {
+      "primary": { "value": "#ffffff", "type": "color" },
+      "accent": { "value": "{blue}", "type": "color" }
+    }
+  },
+  "dark": {
+    "color": {
+      "primary": { "value": "#000000", "type": "color" },
+      "link": { "value": "{color.primary}", "type": "color" }
+    }
+  },
+  "$metadata": { "tokenSetOrder": ["core", "light", "dark"] }
+}`,
+			want: []Token{
+				{Path: "core.blue", Variable: "--core-blue", Value: "#0000ff"},
+				{Path: "light.color.primary", Variable: "--light-color-primary", Value: "#ffffff"},
+				{Path: "light.color.accent", Variable: "--light-color-accent", Value: "#0000ff"},
+				{Path: "dark.color.primary", Variable: "--dark-color-primary", Value: "#000000"},
+				{Path: "dark.color.link", Variable: "--dark-color-link", Value: "#000000"},
+			},
+		},
+		{
+			name: "ambiguous alias across sets",
+			content: `{
+  "light": { "primary": { "value": "#ffffff", "type": "color" } },
+  "dark": { "primary": { "value": "#000000", "type": "color" } },
+  "brand": { "link": { "value": "{primary}", "type": "color" } },
+  "$themes": []
+}`,
+			wantErr: true,
+		},
+		{
+			name:    "conflicting variables",
+			content: `{ "color": { "$type": "color", "brandRed": { "$value": "#ff0000" }, "brand-red": { "$value": "#ee0000" } } }`,
+			wantErr: true,
+		},
+		{
+			name: "unsupported color",
+			content: `{
+  "color": {
+    "$type": "color",
+    "named": { "$value": "red" },
+    "hsl": { "$value": "hsl(0, 100%, 50%)" },
+    "ok": { "$value": "#ff0000" }
+  }
+}`,
+			want: []Token{
+				{Path: "color.ok", Variable: "--color-ok", Value: "#ff0000"},
+			},
+			notes: []string{
+				`skipped token color.named: unsupported color value "red"`,
+				`skipped token color.hsl: unsupported color value "hsl(0, 100%, 50%)"`,
+			},
+		},
+		{
+			name:    "alias cycle",
+			content: `{ "a": { "$type": "color", "$value": "{b}" }, "b": { "$type": "color", "$value": "{a}" } }`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			content: `color: red`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tempDir, "tokens.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write tokens file: %v", err)
+			}
+
+			got, notes, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(notes, tt.notes) {
+				t.Errorf("Load() notes = %q, want %q", notes, tt.notes)
+			}
+		})
+	}
+}
+
+func TestVariableName(t *testing.T) {
+	tests := []struct {
+		path []string
+		want string
+	}{
+		{[]string{"color", "brand", "primary"}, "--color-brand-primary"},
+		{[]string{"color", "brandPrimary"}, "--color-brand-primary"},
+		{[]string{"Color", "gray 100"}, "--color-gray-100"},
+	}
+
+	for _, tt := range tests {
+		if got := VariableName(tt.path); got != tt.want {
+			t.Errorf("VariableName(%v) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}